@@ -52,6 +52,13 @@ func NewClient(token, account string) *Client {
 	}
 }
 
+// WithAccount creates a copy of the client that acts on another account
+func (c *Client) WithAccount(account string) *Client {
+	out := *c
+	out.Account = account
+	return &out
+}
+
 func (c *Client) newRequest(cc context.Context, method, rawPath string, impersonate bool) *request {
 	return c.makeRequest(cc, method, c.Endpoint, rawPath, impersonate)
 }
@@ -177,18 +184,18 @@ func (r *request) doPaginatedJSON(data interface{}) (*PaginationResponse, error)
 	return pagination, r.err
 }
 
-// Create Gemfury API request, and then stream output
-func (r *request) doWithOutput(out io.Writer) error {
+// Create Gemfury API request, and then stream output, returns headers and error
+func (r *request) doWithOutput(out io.Writer) (http.Header, error) {
 	resp, err := r.doCommon()
 	if err != nil {
 		r.err = err
-		return err
+		return nil, err
 	}
 
 	defer resp.Body.Close()
 
 	_, r.err = io.Copy(out, resp.Body)
-	return r.err
+	return resp.Header, r.err
 }
 
 // Wrapper for net/http and http.Client
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"net/http"
 	"net/url"
 	"strings"
 )
@@ -30,11 +31,45 @@ func (c *Client) DumpVersions(cc context.Context, body *PaginationRequest, kindF
 
 // DownloadVersion uses the "download_url" field to download the Version file
 func (c *Client) DownloadVersion(cc context.Context, v *Version) (io.ReadCloser, int64, error) {
+	body, size, _, err := c.DownloadVersionFrom(cc, v, 0)
+	return body, size, err
+}
+
+// DownloadVersionFrom resumes a download at offset using an HTTP Range request.
+// It returns the offset where the body starts, which is zero when the server
+// doesn't support ranges and sends the whole file instead.
+func (c *Client) DownloadVersionFrom(cc context.Context, v *Version, offset int64) (io.ReadCloser, int64, int64, error) {
 	if !strings.HasPrefix(v.DownloadURL, c.Endpoint) {
-		return nil, 0, fmt.Errorf("Download URL not compatible with API client")
+		return nil, 0, 0, fmt.Errorf("Download URL not compatible with API client")
+	}
+
+	path := strings.TrimPrefix(v.DownloadURL, c.Endpoint)
+	req := c.newRequest(cc, "GET", path, true)
+	if offset > 0 && req.Request != nil {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := req.doCommon()
+
+	// Range is past the end of file, so start over
+	if offset > 0 && resp != nil && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		return c.DownloadVersionFrom(cc, v, 0)
+	} else if err != nil {
+		return nil, 0, 0, err
+	}
+
+	// Server ignored Range, and is sending the whole file
+	if offset == 0 || resp.StatusCode != http.StatusPartialContent {
+		return resp.Body, resp.ContentLength, 0, nil
+	}
+
+	// Verify partial content starts where requested
+	var start, end int64
+	rangeHdr := resp.Header.Get("Content-Range")
+	if _, err := fmt.Sscanf(rangeHdr, "bytes %d-%d/", &start, &end); err != nil || start != offset {
+		resp.Body.Close()
+		return c.DownloadVersionFrom(cc, v, 0)
 	}
 
-	path := strings.TrimPrefix(v.DownloadURL, defaultEndpoint)
-	resp, err := c.newRequest(cc, "GET", path, true).doCommon()
-	return resp.Body, resp.ContentLength, err
+	return resp.Body, resp.ContentLength, offset, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"net/url"
+	"time"
 )
 
 // GitList returns a listing of Git repositories for an account
@@ -36,6 +37,11 @@ type GitRepo struct {
 	Stack struct {
 		Name string `json:"name"`
 	} `json:"build_stack"`
+	CloneURL  string     `json:"clone_url,omitempty"`
+	Size      int64      `json:"size"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	PushedAt  *time.Time `json:"pushed_at,omitempty"`
+	LastBuild *GitBuild  `json:"last_build,omitempty"`
 }
 
 // GitInfo returns the details of a specific Git repository
@@ -53,6 +59,21 @@ type gitInfoResponse struct {
 	Repo GitRepo `json:"repo"`
 }
 
+// GitCreate creates an empty Gemfury Git repository, ready for a first push
+func (c *Client) GitCreate(cc context.Context, repo string) (*GitRepo, error) {
+	path := "/git/repos/{acct}?repo[name]=" + url.QueryEscape(repo)
+	req := c.newRequest(cc, "POST", path, false)
+
+	resp := gitInfoResponse{}
+	err := req.doJSON(&resp)
+	return &resp.Repo, err
+}
+
+// GitRemoteURL returns the HTTPS clone URL of a repository for the client's account
+func (c *Client) GitRemoteURL(repo string) (string, error) {
+	return c.renderURITemplate("https://git.fury.io/{acct}/" + url.PathEscape(repo) + ".git")
+}
+
 // GitDestroy either fully removes a Gemfury Git repository, or resets the repo
 // by deleting content but keeping its history, configuraion, and related metadata.
 func (c *Client) GitDestroy(cc context.Context, repo string, resetOnly bool) error {
@@ -76,12 +97,17 @@ func (c *Client) GitRename(cc context.Context, repo, newName string) error {
 	return req.doJSON(nil)
 }
 
-// GitRename renames a Gemfury Git repository
-func (c *Client) GitRebuild(cc context.Context, out io.Writer, repo, revision string) error {
+// GitRebuild triggers a build of a Gemfury Git repository, streams its output,
+// and returns the ID of the triggered build
+func (c *Client) GitRebuild(cc context.Context, out io.Writer, repo, revision string) (string, error) {
 	path := "/git/repos/{acct}/" + url.PathEscape(repo) + "/builds"
 	if revision != "" {
 		path = path + "?build[revision]=" + url.QueryEscape(revision)
 	}
 	req := c.newRequest(cc, "POST", path, false)
-	return req.doWithOutput(out)
+	hdr, err := req.doWithOutput(out)
+	if err != nil {
+		return "", err
+	}
+	return hdr.Get("X-Build-Id"), nil
 }
@@ -0,0 +1,93 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+)
+
+// GitBuilds returns a listing of builds for a Git repository, newest first
+func (c *Client) GitBuilds(cc context.Context, repo string, body *PaginationRequest) (*GitBuildsResponse, error) {
+	path := "/git/repos/{acct}/" + url.PathEscape(repo) + "/builds"
+	req := c.newRequest(cc, "GET", path, false)
+
+	if body != nil {
+		c.prepareJSONBody(req, body)
+	}
+
+	resp := GitBuildsResponse{}
+	pagination, err := req.doPaginatedJSON(&resp.Root)
+	resp.Pagination = pagination
+
+	return &resp, err
+}
+
+// GitBuildsResponse represents details from Git Builds API call
+type GitBuildsResponse struct {
+	Pagination *PaginationResponse
+	Root       struct {
+		Builds []*GitBuild
+	}
+}
+
+// GitBuild represents Git Build JSON
+type GitBuild struct {
+	ID         string           `json:"id"`
+	Revision   string           `json:"revision"`
+	Status     string           `json:"status"`
+	CreatedBy  *AccountResponse `json:"created_by,omitempty"`
+	CreatedAt  time.Time        `json:"created_at"`
+	FinishedAt *time.Time       `json:"finished_at,omitempty"`
+}
+
+// Failed is true when build has finished unsuccessfully
+func (b GitBuild) Failed() bool {
+	switch b.Status {
+	case "failed", "error", "canceled", "cancelled":
+		return true
+	}
+	return false
+}
+
+// Duration of a finished build, or zero if still running
+func (b GitBuild) Duration() time.Duration {
+	if f := b.FinishedAt; f != nil {
+		return f.Sub(b.CreatedAt)
+	}
+	return 0
+}
+
+func (b GitBuild) DisplayCreatedBy() string {
+	if a := b.CreatedBy; a != nil {
+		return a.Name
+	}
+	return "N/A"
+}
+
+// GitBuildLog streams the log of a build, and optionally
+// follows it until the build finishes
+func (c *Client) GitBuildLog(cc context.Context, out io.Writer, repo, build string, follow bool) error {
+	path := "/git/repos/{acct}/" + url.PathEscape(repo) + "/builds/" + url.PathEscape(build) + "/log"
+	if follow {
+		path = path + "?follow=1"
+	}
+	req := c.newRequest(cc, "GET", path, false)
+	_, err := req.doWithOutput(out)
+	return err
+}
+
+// GitBuildInfo returns the details of a specific build
+func (c *Client) GitBuildInfo(cc context.Context, repo, build string) (*GitBuild, error) {
+	path := "/git/repos/{acct}/" + url.PathEscape(repo) + "/builds/" + url.PathEscape(build)
+	req := c.newRequest(cc, "GET", path, false)
+
+	resp := gitBuildInfoResponse{}
+	err := req.doJSON(&resp)
+	return &resp.Build, err
+}
+
+// gitBuildInfoResponse represents details from Git Build Info API call
+type gitBuildInfoResponse struct {
+	Build GitBuild `json:"build"`
+}
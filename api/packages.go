@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -61,6 +62,14 @@ func (c *Client) Version(cc context.Context, pkg, ver string) (*Version, error)
 	return &resp, err
 }
 
+// PackageSetPrivacy makes a package private or public
+func (c *Client) PackageSetPrivacy(cc context.Context, pkg string, private bool) error {
+	path := "/packages/" + url.PathEscape(pkg)
+	path = path + "?package[private]=" + strconv.FormatBool(private)
+	req := c.newRequest(cc, "PATCH", path, true)
+	return req.doJSON(nil)
+}
+
 // PackageResponse represents details from Packages API call
 type PackagesResponse struct {
 	Pagination *PaginationResponse
@@ -106,6 +115,7 @@ type Version struct {
 	CreatedAt   time.Time        `json:"created_at"`
 	DownloadURL string           `json:"download_url"`
 	Filename    string           `json:"filename"`
+	Size        int64            `json:"size"`
 	Digests     VersionDigests   `json:"digests"`
 }
 
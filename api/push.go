@@ -6,17 +6,29 @@ import (
 	"mime/multipart"
 )
 
-// PushPkg uploads a single package file to the current account
-func (c *Client) PushPkg(cc context.Context, filename string, isPublic bool, r io.Reader) error {
+// PushOptions are the per-file upload settings for PushPkg
+type PushOptions struct {
+	Public bool   // Create as public package
+	Kind   string // Override auto-detected package kind
+}
+
+// PushPkg uploads a single package file to the current account,
+// and returns the Version created from the uploaded file
+func (c *Client) PushPkg(cc context.Context, filename string, opts PushOptions, r io.Reader) (*Version, error) {
 	bodyR, bodyW := io.Pipe()
 	writer := multipart.NewWriter(bodyW)
 
 	go func() {
 		// Public vs. private
-		if isPublic {
+		if opts.Public {
 			writer.WriteField("public", "true")
 		}
 
+		// Explicit package kind
+		if opts.Kind != "" {
+			writer.WriteField("kind", opts.Kind)
+		}
+
 		// Stream file content
 		ff, err := writer.CreateFormFile("file", filename)
 		if err != nil {
@@ -38,6 +50,7 @@ func (c *Client) PushPkg(cc context.Context, filename string, isPublic bool, r i
 	req.Header.Set("Content-Type", writer.FormDataContentType())
 	req.Request.Body = bodyR
 
-	err := req.doJSON(nil)
-	return err
+	resp := Version{}
+	err := req.doJSON(&resp)
+	return &resp, err
 }
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"net/url"
+	"time"
 )
 
 // AddCollaborator invites a collaborator via username or email
@@ -15,6 +16,13 @@ func (c *Client) AddCollaborator(cc context.Context, name, role string) error {
 	return req.doJSON(nil)
 }
 
+// UpdateCollaborator changes the role of an existing collaborator
+func (c *Client) UpdateCollaborator(cc context.Context, name, role string) error {
+	path := "/collaborators/" + url.PathEscape(name) + "?role=" + url.QueryEscape(role)
+	req := c.newRequest(cc, "PATCH", path, true)
+	return req.doJSON(nil)
+}
+
 // RemoveCollaborator removes a collaborator via username or email
 func (c *Client) RemoveCollaborator(cc context.Context, name string) error {
 	req := c.newRequest(cc, "DELETE", "/collaborators/"+url.PathEscape(name), true)
@@ -59,6 +67,50 @@ type MembersResponse struct {
 
 // Member represents Member JSON
 type Member struct {
-	Role string `json:"role"`
+	Role      string     `json:"role"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 	AccountResponse
 }
+
+// Invitations returns pending invitations to collaborate on the account
+func (c *Client) Invitations(cc context.Context, body *PaginationRequest) (*InvitationsResponse, error) {
+	req := c.newRequest(cc, "GET", "/invitations", true)
+
+	if body != nil {
+		c.prepareJSONBody(req, body)
+	}
+
+	resp := InvitationsResponse{}
+	pagination, err := req.doPaginatedJSON(&resp.Invitations)
+	resp.Pagination = pagination
+
+	return &resp, err
+}
+
+// ResendInvitation sends the invitation email again
+func (c *Client) ResendInvitation(cc context.Context, id string) error {
+	path := "/invitations/" + url.PathEscape(id) + "/resend"
+	req := c.newRequest(cc, "POST", path, true)
+	return req.doJSON(nil)
+}
+
+// CancelInvitation revokes a pending invitation
+func (c *Client) CancelInvitation(cc context.Context, id string) error {
+	req := c.newRequest(cc, "DELETE", "/invitations/"+url.PathEscape(id), true)
+	return req.doJSON(nil)
+}
+
+// InvitationsResponse represents details from Invitations API call
+type InvitationsResponse struct {
+	Pagination  *PaginationResponse
+	Invitations []*Invitation
+}
+
+// Invitation represents Invitation JSON
+type Invitation struct {
+	ID        string           `json:"id"`
+	Email     string           `json:"email"`
+	Role      string           `json:"role"`
+	CreatedAt time.Time        `json:"created_at"`
+	InvitedBy *AccountResponse `json:"invited_by,omitempty"`
+}
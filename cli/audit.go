@@ -0,0 +1,288 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+
+	"bufio"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// NewCmdAudit is the root for audit reports
+func NewCmdAudit() *cobra.Command {
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Security audit reports",
+	}
+
+	auditCmd.AddCommand(NewCmdAuditAccess())
+	auditCmd.AddCommand(NewCmdAuditPublic())
+
+	return auditCmd
+}
+
+// NewCmdAuditAccess reports members of every account you collaborate on
+func NewCmdAuditAccess() *cobra.Command {
+	var jsonFlag, csvFlag bool
+	var allowFlag string
+
+	accessCmd := &cobra.Command{
+		Use:   "access",
+		Short: "Report members of all your accounts",
+		Long: `Report members and their roles for every account you collaborate on.
+
+With --allow, members missing from the allow-list file are flagged.
+The file lists one email or username per line. Lines starting with
+"@" allow a whole email domain, such as "@example.com".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if jsonFlag && csvFlag {
+				return fmt.Errorf("Please use either --json or --csv")
+			}
+
+			var allowed *allowList
+			if allowFlag != "" {
+				list, err := readAllowList(allowFlag)
+				if err != nil {
+					return err
+				}
+				allowed = list
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			rows, err := auditAccessRows(cc, c, allowed)
+			if len(rows) == 0 {
+				if err == nil {
+					term.Println("No collaborations found for this account")
+				}
+				return err
+			}
+
+			if jsonFlag {
+				if jerr := termPrintJSON(term, rows); jerr != nil {
+					return jerr
+				}
+				return err
+			}
+
+			if csvFlag {
+				w := csv.NewWriter(term.IOOut())
+				w.Write([]string{"account", "username", "email", "name", "role", "allowed"})
+				for _, r := range rows {
+					w.Write([]string{r.Account, r.Username, r.Email, r.Name, r.Role, r.allowedString()})
+				}
+				w.Flush()
+				if werr := w.Error(); werr != nil {
+					return werr
+				}
+				return err
+			}
+
+			// Print results
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "account\tusername\temail\trole\tallowed\n")
+			for _, r := range rows {
+				flag := r.allowedString()
+				if r.Allowed != nil && !*r.Allowed {
+					flag = "NOT ALLOWED"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Account, r.Username, r.Email, r.Role, flag)
+			}
+
+			w.Flush()
+			return err
+		},
+	}
+
+	// Flags and options
+	accessCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON")
+	accessCmd.Flags().BoolVar(&csvFlag, "csv", false, "Output as CSV")
+	accessCmd.Flags().StringVar(&allowFlag, "allow", "", "Flag members missing from allow-list file")
+
+	return accessCmd
+}
+
+// NewCmdAuditPublic lists packages anyone can download
+func NewCmdAuditPublic() *cobra.Command {
+	var jsonFlag bool
+
+	publicCmd := &cobra.Command{
+		Use:   "public",
+		Short: "List public packages in this account",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			packages := []*api.Package{}
+			err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+				resp, err := c.Packages(cc, pageReq)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, p := range resp.Packages {
+					if !p.IsPrivate {
+						packages = append(packages, p)
+					}
+				}
+
+				return resp.Pagination, nil
+			})
+
+			if jsonFlag {
+				if jerr := termPrintJSON(term, packages); jerr != nil {
+					return jerr
+				}
+				return err
+			}
+
+			// Handle no public packages
+			if len(packages) == 0 {
+				term.Println("No public packages found in this account")
+				return err
+			}
+
+			// Print results
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "name\tkind\tversion\n")
+			for _, p := range packages {
+				fmt.Fprintf(w, "%s\t%s\t%s\n", p.Name, p.Kind, p.DisplayVersion())
+			}
+
+			w.Flush()
+			return err
+		},
+	}
+
+	// Flags and options
+	publicCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON")
+
+	return publicCmd
+}
+
+// auditAccessRow is a member's role in one account
+type auditAccessRow struct {
+	Account  string `json:"account"`
+	Username string `json:"username"`
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	Role     string `json:"role"`
+	Allowed  *bool  `json:"allowed,omitempty"`
+}
+
+// Yes/no when checked against an allow-list, otherwise empty
+func (r auditAccessRow) allowedString() string {
+	if r.Allowed == nil {
+		return ""
+	} else if *r.Allowed {
+		return "yes"
+	}
+	return "no"
+}
+
+// Members of each collaborating account, impersonating that account.
+// Accounts where members can't be listed are skipped and reported.
+func auditAccessRows(cc context.Context, c *api.Client, allowed *allowList) ([]auditAccessRow, error) {
+	accounts := []*api.Member{}
+	err := iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+		resp, err := c.Collaborations(cc, pageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, resp.Members...)
+		return resp.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []auditAccessRow{}
+	var multiErr *multierror.Error
+
+	for _, a := range accounts {
+		client := c.WithAccount(a.Username)
+		err := iterateAll(cc, false, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+			resp, err := client.Members(cc, pageReq)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, m := range resp.Members {
+				row := auditAccessRow{
+					Account:  a.Username,
+					Username: m.Username,
+					Email:    m.Email,
+					Name:     m.Name,
+					Role:     m.Role,
+				}
+				if allowed != nil {
+					ok := allowed.Contains(m.Email, m.Username)
+					row.Allowed = &ok
+				}
+				rows = append(rows, row)
+			}
+
+			return resp.Pagination, nil
+		})
+
+		if err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", a.Username, err))
+			log.Printf("Problem listing members of %q: %s\n", a.Username, err)
+		}
+	}
+
+	return rows, multiErr.ErrorOrNil()
+}
+
+// allowList holds allowed emails, usernames, and "@domain" entries
+type allowList struct {
+	entries map[string]bool
+}
+
+func readAllowList(path string) (*allowList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	list := &allowList{entries: map[string]bool{}}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && !strings.HasPrefix(line, "#") {
+			list.entries[strings.ToLower(line)] = true
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+// Contains is true when email, its domain, or username is allowed
+func (l *allowList) Contains(email, username string) bool {
+	email, username = strings.ToLower(email), strings.ToLower(username)
+	if (email != "" && l.entries[email]) || (username != "" && l.entries[username]) {
+		return true
+	}
+
+	at := strings.LastIndex(email, "@")
+	return at >= 0 && l.entries[email[at:]]
+}
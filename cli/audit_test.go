@@ -0,0 +1,142 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// ==== audit access ====
+
+func TestAuditAccessCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	server := testAuditAccessServer(t)
+	defer server.Close()
+
+	allowPath := filepath.Join(t.TempDir(), "allow.txt")
+	os.WriteFile(allowPath, []byte("# Staff\n@example.com\ncontractor\n"), 0600)
+
+	// Each case is args and expected output
+	cases := []struct {
+		args []string
+		out  string
+	}{{
+		[]string{"--allow", allowPath},
+		"account username email role allowed " +
+			"my-name me me@example.com owner yes " +
+			"org-name me me@example.com owner yes " +
+			"org-name contractor c@other.com push yes " +
+			"org-name stranger s@other.com pull NOT ALLOWED",
+	}, {
+		[]string{"--csv"},
+		"account,username,email,name,role,allowed " +
+			"my-name,me,me@example.com,Me,owner, " +
+			"org-name,me,me@example.com,Me,owner, " +
+			"org-name,contractor,c@other.com,,push, " +
+			"org-name,stranger,s@other.com,,pull,",
+	}, {
+		[]string{"--json", "--allow", allowPath},
+		`[ { "account": "my-name", "username": "me", "email": "me@example.com", "name": "Me", "role": "owner", "allowed": true }, ` +
+			`{ "account": "org-name", "username": "me", "email": "me@example.com", "name": "Me", "role": "owner", "allowed": true }, ` +
+			`{ "account": "org-name", "username": "contractor", "email": "c@other.com", "name": "", "role": "push", "allowed": true }, ` +
+			`{ "account": "org-name", "username": "stranger", "email": "s@other.com", "name": "", "role": "pull", "allowed": false } ]`,
+	}}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommandNoErr(cc, append([]string{"audit", "access"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := compactString(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
+	}
+}
+
+func TestAuditAccessCommandUnauthorized(t *testing.T) {
+	server := testAuditAccessServer(t)
+	testCommandLoginPreCheck(t, []string{"audit", "access"}, server)
+	server.Close()
+}
+
+func TestAuditAccessCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/collaborations", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"audit", "access"}, server)
+	server.Close()
+}
+
+// ==== audit public ====
+
+func TestAuditPublicCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServerPaginated(t, "GET", "/packages", packagesResponses, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"audit", "public"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "name kind version pkg-ruby ruby 1.1.1"
+	if outStr := compactString(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+}
+
+func TestAuditPublicCommandUnauthorized(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/packages", "[]", 200)
+	testCommandLoginPreCheck(t, []string{"audit", "public"}, server)
+	server.Close()
+}
+
+func TestAuditPublicCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/packages", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"audit", "public"}, server)
+	server.Close()
+}
+
+// Serves collaborations, and members for each account via impersonation
+func testAuditAccessServer(t *testing.T) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/collaborations", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(`[{ "name": "Me", "username": "my-name", "role": "owner" },
+				{ "name": "Org", "username": "org-name", "role": "owner" }]`))
+		})
+		mux.HandleFunc("/members", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			me := `{ "name": "Me", "username": "me", "email": "me@example.com", "role": "owner" }`
+			switch r.URL.Query().Get("as") {
+			case "my-name":
+				w.Write([]byte("[" + me + "]"))
+			case "org-name":
+				w.Write([]byte("[" + me + `,
+					{ "username": "contractor", "email": "c@other.com", "role": "push" },
+					{ "username": "stranger", "email": "s@other.com", "role": "pull" }]`))
+			default:
+				t.Errorf("Unexpected account %q", r.URL.Query().Get("as"))
+				w.WriteHeader(404)
+			}
+		})
+	})
+}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+)
+
+var (
+	errChecksumMismatch = errors.New("Checksum mismatch")
+)
+
+// versionDigest hashes content as it is written, and checks
+// it against every digest the API provides for a Version
+type versionDigest struct {
+	hashes   []hash.Hash
+	names    []string
+	expected []string
+	io.Writer
+}
+
+func newVersionDigest(d api.VersionDigests) *versionDigest {
+	vd := &versionDigest{}
+
+	vd.add("SHA-512", d.SHA512, sha512.New())
+	vd.add("SHA-256", d.SHA256, sha256.New())
+	vd.add("SHA-1", d.SHA1, sha1.New())
+	vd.add("MD5", d.MD5, md5.New())
+
+	writers := make([]io.Writer, 0, len(vd.hashes))
+	for _, h := range vd.hashes {
+		writers = append(writers, h)
+	}
+
+	vd.Writer = io.MultiWriter(writers...)
+	return vd
+}
+
+func (vd *versionDigest) add(name, expected string, h hash.Hash) {
+	if expected != "" {
+		vd.names = append(vd.names, name)
+		vd.expected = append(vd.expected, expected)
+		vd.hashes = append(vd.hashes, h)
+	}
+}
+
+// Empty is true when API has not provided any digests
+func (vd *versionDigest) Empty() bool {
+	return len(vd.hashes) == 0
+}
+
+// Verify compares everything written so far with expected digests
+func (vd *versionDigest) Verify() error {
+	for i, h := range vd.hashes {
+		sum := fmt.Sprintf("%x", h.Sum(nil))
+		if !strings.EqualFold(sum, vd.expected[i]) {
+			return fmt.Errorf("%w (%s)", errChecksumMismatch, vd.names[i])
+		}
+	}
+	return nil
+}
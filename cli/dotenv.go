@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	dotenvKeyRE   = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+	dotenvPlainRE = regexp.MustCompile(`^[A-Za-z0-9_./:@+,=-]+$`)
+)
+
+// parseDotenv reads KEY=VALUE pairs in the common ".env" format,
+// with comments, optional "export" prefix, and quoted values
+func parseDotenv(r io.Reader) (map[string]string, error) {
+	out := map[string]string{}
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		pair := strings.SplitN(line, "=", 2)
+		key := strings.TrimSpace(pair[0])
+		if len(pair) != 2 || !dotenvKeyRE.MatchString(key) {
+			return nil, fmt.Errorf("Invalid line %d: expected KEY=VALUE", lineNum)
+		}
+
+		val := strings.TrimSpace(pair[1])
+		switch {
+		case strings.HasPrefix(val, `"`):
+			end := closingQuote(val)
+			if end < 0 {
+				return nil, fmt.Errorf("Invalid line %d: unterminated quote", lineNum)
+			}
+			val = unescapeDotenv(val[1:end])
+		case strings.HasPrefix(val, `'`):
+			end := strings.Index(val[1:], `'`)
+			if end < 0 {
+				return nil, fmt.Errorf("Invalid line %d: unterminated quote", lineNum)
+			}
+			val = val[1 : end+1]
+		default:
+			if at := strings.Index(val, " #"); at >= 0 {
+				val = strings.TrimSpace(val[:at])
+			}
+		}
+
+		out[key] = val
+	}
+
+	return out, scanner.Err()
+}
+
+// Position of unescaped closing double quote
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+		} else if s[i] == '"' {
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\r`, "\r", `\t`, "\t", `\"`, `"`, `\\`, `\`).Replace(s)
+}
+
+// formatDotenv writes a KEY=VALUE line, quoting value where needed
+func formatDotenv(key, val string) string {
+	if dotenvPlainRE.MatchString(val) {
+		return key + "=" + val
+	}
+
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(val)
+	return key + `="` + escaped + `"`
+}
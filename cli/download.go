@@ -9,7 +9,6 @@ import (
 	"github.com/spf13/cobra"
 
 	"context"
-	"crypto/sha512"
 	"errors"
 	"fmt"
 	"io"
@@ -30,26 +29,56 @@ func NewCmdBeta() *cobra.Command {
 		Short:  "Experimental features",
 	}
 
+	// Download graduated from beta, but remains for compatibility
+	downloadCmd := NewCmdDownload()
+	downloadCmd.Deprecated = `use "fury download" instead`
+
 	betaCmd.AddCommand(NewCmdBackup())
-	betaCmd.AddCommand(NewCmdDownload())
+	betaCmd.AddCommand(downloadCmd)
 
 	return betaCmd
 }
 
+// downloadOptions are the flags for "download"
+type downloadOptions struct {
+	Kind        string
+	OutputDir   string
+	Output      string
+	AllVersions bool
+}
+
 // NewCmdDownload creates a Cobra command for "download"
 func NewCmdDownload() *cobra.Command {
-	return &cobra.Command{
-		Use:   "download PACKAGE@VERSION",
-		Short: "Download a package to the current directory",
+	opts := downloadOptions{}
+
+	downloadCmd := &cobra.Command{
+		Use:   "download [KIND:]PACKAGE[@VERSION]",
+		Short: "Download package files",
+		Long: `Download package files to the current directory or --output-dir.
+
+VERSION is an exact version, "latest", or a range like "^2", "~1.2",
+">=1.0 <2", or "1.x". Without a VERSION, the latest version is used,
+or every version with --all-versions.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return downloadVersions(cmd, args)
+			return downloadVersions(cmd, args, opts)
 		},
 	}
+
+	// Flags and options
+	flags := downloadCmd.Flags()
+	flags.StringVar(&opts.Kind, "kind", "", "Filter to one kind of package")
+	flags.StringVar(&opts.OutputDir, "output-dir", ".", "Destination directory")
+	flags.StringVarP(&opts.Output, "output", "o", "", "Destination file, or - for stdout")
+	flags.BoolVar(&opts.AllVersions, "all-versions", false, "Download all matching versions")
+
+	return downloadCmd
 }
 
-func downloadVersions(cmd *cobra.Command, args []string) error {
+func downloadVersions(cmd *cobra.Command, args []string, opts downloadOptions) error {
 	if len(args) < 1 {
-		return fmt.Errorf("Please specify at least one version")
+		return fmt.Errorf("Please specify at least one package")
+	} else if opts.Output != "" && (len(args) > 1 || opts.AllVersions) {
+		return fmt.Errorf("Output file can only be used with a single version")
 	}
 
 	cc := cmd.Context()
@@ -60,31 +89,132 @@ func downloadVersions(cmd *cobra.Command, args []string) error {
 
 	var multiErr *multierror.Error
 	for _, arg := range args {
-		var pkg, ver string
+		pkg, spec := splitPackageVersion(arg)
 
-		if at := strings.LastIndex(arg, "@"); at > 0 {
-			pkg, ver = arg[0:at], arg[at+1:]
-		} else {
-			err := fmt.Errorf("Argument format: PACKAGE@VERSION")
-			multiErr = multierror.Append(multiErr, err)
-			continue
+		// Kind flag applies unless specified as "kind:name"
+		if kind, _ := splitPackageKind(pkg); kind == "" && opts.Kind != "" {
+			pkg = opts.Kind + ":" + pkg
 		}
 
-		v, err := c.Version(cc, pkg, ver)
+		versions, err := resolveVersions(cc, c, pkg, spec, opts.AllVersions)
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 			continue
+		} else if len(versions) == 0 {
+			err := fmt.Errorf("No versions found for %s", arg)
+			multiErr = multierror.Append(multiErr, err)
+			continue
 		}
 
-		filename := strings.ReplaceAll(v.Filename, string(filepath.Separator), "_")
-		if err := downloadVersion(cc, c, v, ".", filename); err != nil {
-			multiErr = multierror.Append(multiErr, err)
+		// Single file to a specific path or stdout
+		if out := opts.Output; out != "" {
+			if len(versions) > 1 {
+				err := fmt.Errorf("%s matches %d files, use --output-dir", arg, len(versions))
+				multiErr = multierror.Append(multiErr, err)
+			} else if out == "-" {
+				err := downloadToWriter(cc, c, versions[0], ctx.Terminal(cc).IOOut())
+				multiErr = multierror.Append(multiErr, err)
+			} else {
+				err := downloadVersion(cc, c, versions[0], filepath.Dir(out), filepath.Base(out))
+				multiErr = multierror.Append(multiErr, err)
+			}
+			continue
+		}
+
+		for _, v := range versions {
+			filename := strings.ReplaceAll(v.Filename, string(filepath.Separator), "_")
+			if err := downloadVersion(cc, c, v, opts.OutputDir, filename); err != nil {
+				multiErr = multierror.Append(multiErr, err)
+			}
 		}
 	}
 
 	return multiErr.Unwrap()
 }
 
+// resolveVersions finds files for an exact version, "latest", or a version range.
+// Without --all-versions, only the highest matching version of each package is kept.
+func resolveVersions(cc context.Context, c *api.Client, pkg, spec string, all bool) ([]*api.Version, error) {
+	if spec != "" && spec != "latest" && !isVersionConstraint(spec) {
+		return filterVersions(cc, c, pkg, spec)
+	}
+
+	versions, err := filterVersions(cc, c, pkg, "")
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]*api.Version, 0, len(versions))
+	for _, v := range versions {
+		switch {
+		case spec == "latest" || (spec == "" && !all):
+			if latest := packageLatestVersion(v.Package); latest == "" || latest == v.Version {
+				matched = append(matched, v)
+			}
+		case spec == "" || matchVersionConstraint(spec, v.Version):
+			matched = append(matched, v)
+		}
+	}
+
+	if all {
+		return matched, nil
+	}
+
+	// Highest version for each package (may have multiple files)
+	highest := map[string]string{}
+	for _, v := range matched {
+		pid := versionPackageID(v)
+		if h, ok := highest[pid]; !ok || compareVersions(v.Version, h) > 0 {
+			highest[pid] = v.Version
+		}
+	}
+
+	out := make([]*api.Version, 0, len(highest))
+	for _, v := range matched {
+		if highest[versionPackageID(v)] == v.Version {
+			out = append(out, v)
+		}
+	}
+
+	return out, nil
+}
+
+// Latest release of a package, or latest prerelease if there are no releases
+func packageLatestVersion(p *api.Package) string {
+	if p == nil {
+		return ""
+	} else if r := p.ReleaseVersion; r != nil && r.Version != "" {
+		return r.Version
+	}
+	return p.LatestVersion.Version
+}
+
+func versionPackageID(v *api.Version) string {
+	if p := v.Package; p != nil {
+		return p.ID
+	}
+	return ""
+}
+
+// Stream version file without status output, such as for stdout
+func downloadToWriter(cc context.Context, client *api.Client, v *api.Version, out io.Writer) error {
+	body, _, err := client.DownloadVersion(cc, v)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Output can't be taken back, but a mismatch is still reported
+	digest := newVersionDigest(v.Digests)
+	if _, err = io.Copy(io.MultiWriter(out, digest), body); err != nil {
+		return err
+	} else if err := digest.Verify(); err != nil {
+		return fmt.Errorf("%s: %w", v.Filename, err)
+	}
+
+	return nil
+}
+
 // NewCmdBackup creates a Cobra command for "backup"
 func NewCmdBackup() *cobra.Command {
 	var kindFlag string
@@ -179,34 +309,76 @@ func downloadVersion(cc context.Context, client *api.Client, v *api.Version, des
 		return err
 	}
 
-	// Open file for writing. It must not exist, otherwise fail
-	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0600)
+	// Download into a ".part" file, renamed only once verified.
+	// An existing ".part" from an interrupted download is resumed.
+	partPath := path + ".part"
+	var offset int64
+	if s, err := os.Stat(partPath); err == nil {
+		offset = s.Size()
+	}
+
+	// Request file from Gemfury API, resuming if server allows it
+	body, size, start, err := client.DownloadVersionFrom(cc, v, offset)
 	if err != nil {
 		return err
 	}
-	defer file.Close()
+	defer body.Close()
 
-	// Request file from Gemfury API
-	body, size, err := client.DownloadVersion(cc, v)
+	// Created only once the request succeeds, to not leave empty files
+	file, err := os.OpenFile(partPath, os.O_RDWR|os.O_CREATE, 0600)
 	if err != nil {
 		return err
 	}
-	defer body.Close()
+	defer file.Close()
+
+	// Hash what was previously downloaded, or start over
+	digest := newVersionDigest(v.Digests)
+	if start > 0 {
+		if _, err := io.Copy(digest, io.NewSectionReader(file, 0, start)); err != nil {
+			return err
+		}
+	}
+	if err := file.Truncate(start); err != nil {
+		return err
+	} else if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return err
+	}
 
 	// Wrap with status bar
 	bar := term.StartProgress(size, fmt.Sprintf(statusFmt+" ", "⌛"))
 	reader := bar.NewProxyReader(body)
 
-	// Download and write to disk
-	_, err = io.Copy(file, reader)
+	// Download and write to disk, hashing along the way
+	_, err = io.Copy(io.MultiWriter(file, digest), reader)
 	bar.Finish()
 
-	// Status output
-	if err == nil {
-		term.Printf(statusFmt+"\n", "💾")
+	if err != nil {
+		if cc.Err() != nil {
+			term.Printf(statusFmt+" (INTERRUPTED)\n", "⏸")
+			return cc.Err() // Keep ".part" to resume later
+		}
+		return err
 	}
 
-	return err
+	if err := digest.Verify(); err != nil {
+		term.Printf(statusFmt+" (CHECKSUM MISMATCH)\n", "❌")
+		file.Close()
+		os.Remove(partPath) // Corrupt, so don't resume
+		return fmt.Errorf("%s: %w", v.Filename, err)
+	}
+
+	// Flush to disk before atomically moving into place
+	if err := file.Sync(); err != nil {
+		return err
+	} else if err := file.Close(); err != nil {
+		return err
+	} else if err := os.Rename(partPath, path); err != nil {
+		return err
+	}
+
+	// Status output
+	term.Printf(statusFmt+"\n", "💾")
+	return nil
 }
 
 // Validate checksum for file
@@ -220,7 +392,7 @@ func backupCheckPath(term terminal.Terminal, v *api.Version, path, statusFmt str
 		return fmt.Errorf("Dir exists: %s", path)
 	}
 
-	if v == nil || v.Digests.SHA512 == "" {
+	if v == nil || newVersionDigest(v.Digests).Empty() {
 		term.Printf(statusFmt+" (WARNING: No checksum provided by API)\n", "❓")
 		return backupSkip // API should always have digests (theoretically)
 	}
@@ -231,13 +403,12 @@ func backupCheckPath(term terminal.Terminal, v *api.Version, path, statusFmt str
 	}
 	defer file.Close()
 
-	hash := sha512.New()
-	if _, err := io.Copy(hash, file); err != nil {
+	digest := newVersionDigest(v.Digests)
+	if _, err := io.Copy(digest, file); err != nil {
 		return err
 	}
 
-	sum := fmt.Sprintf("%x", hash.Sum(nil))
-	if exp := v.Digests.SHA512; exp != sum {
+	if err := digest.Verify(); err != nil {
 		term.Printf(statusFmt+" (CHECKSUM MISMATCH)\n", "❌")
 		prompt := promptui.Prompt{
 			Label:   "Do you want to delete and redownload? [y/N]",
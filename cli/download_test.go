@@ -0,0 +1,345 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+const downloadVersionJSON = `{
+	"id": "ver_%[2]s",
+	"version": "%[2]s",
+	"filename": "foo-%[2]s.tgz",
+	"download_url": "%[1]s/files/foo-%[2]s.tgz",
+	"package": %[3]s
+}`
+
+var downloadVersions = []string{"1.0.0", "1.2.0", "2.0.0-beta"}
+
+const downloadPackageJSON = `{
+	"id": "pkg_x9y8z7",
+	"name": "foo",
+	"kind_key": "js",
+	"latest_version": { "version": "2.0.0-beta" },
+	"release_version": { "version": "1.2.0" }
+}`
+
+// ==== DOWNLOAD ====
+
+func TestDownloadCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	server := testDownloadServer(t)
+	defer server.Close()
+
+	// Each case is args and expected downloaded files
+	cases := []struct {
+		args  []string
+		files []string
+	}{
+		{[]string{"npm:foo@latest"}, []string{"foo-1.2.0.tgz"}},
+		{[]string{"foo", "--kind", "js"}, []string{"foo-1.2.0.tgz"}},
+		{[]string{"foo@^1"}, []string{"foo-1.2.0.tgz"}},
+		{[]string{"foo@~1.0"}, []string{"foo-1.0.0.tgz"}},
+		{[]string{"foo@1.0.0"}, []string{"foo-1.0.0.tgz"}},
+		{[]string{"foo@^1", "--all-versions"}, []string{"foo-1.0.0.tgz", "foo-1.2.0.tgz"}},
+		{[]string{"foo", "--all-versions"}, []string{"foo-1.0.0.tgz", "foo-1.2.0.tgz", "foo-2.0.0-beta.tgz"}},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		dir := t.TempDir()
+		args := append([]string{"download", "--output-dir", dir}, c.args...)
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		files := []string{}
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			files = append(files, e.Name())
+		}
+
+		sort.Strings(files)
+		if fmt.Sprint(files) != fmt.Sprint(c.files) {
+			t.Errorf("%v: Expected files %q, got %q", c.args, c.files, files)
+		}
+	}
+}
+
+func TestDownloadCommandScopedPackage(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	query := ""
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			query = "kind=" + q.Get("kind") + " name=" + q.Get("name") + " version=" + q.Get("version")
+			item := fmt.Sprintf(downloadVersionJSON, "http://"+r.Host, "1.0.0", "null")
+			w.Write([]byte("[" + item + "]"))
+		})
+		mux.HandleFunc("/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("content"))
+		})
+	})
+	defer server.Close()
+
+	// Each case is arg and expected query
+	cases := map[string]string{
+		"npm:@scope/left-pad":       "kind=js name=@scope/left-pad version=",
+		"npm:@scope/left-pad@1.0.0": "kind=js name=@scope/left-pad version=1.0.0",
+		"@scope/left-pad@1.0.0":     "kind= name=@scope/left-pad version=1.0.0",
+	}
+
+	for arg, exp := range cases {
+		cc := cli.TestContext(terminal.NewForTest(), auth)
+		ctx.GlobalFlags(cc).Endpoint = server.URL
+
+		args := []string{"download", "--output-dir", t.TempDir(), arg}
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%s: %s", arg, err)
+		} else if query != exp {
+			t.Errorf("%s: Expected query %q, got %q", arg, exp, query)
+		}
+	}
+}
+
+func TestDownloadCommandStdout(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testDownloadServer(t)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"download", "foo@^1", "--output", "-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if exp, outStr := "content of foo-1.2.0.tgz", string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// Multiple files cannot be written to stdout
+	err = runCommand(cc, []string{"download", "foo", "--all-versions", "-o", "-"})
+	if err == nil || !strings.Contains(err.Error(), "single version") {
+		t.Errorf("Expected single version error, got %v", err)
+	}
+}
+
+func TestDownloadCommandChecksum(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	content := []byte("content of foo-1.0.0.tgz")
+	digests := ""
+
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `[{
+				"id": "ver_a1",
+				"version": "1.0.0",
+				"filename": "foo-1.0.0.tgz",
+				"download_url": "http://%s/files/foo-1.0.0.tgz",
+				"digests": %s
+			}]`, r.Host, digests)
+		})
+		mux.HandleFunc("/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write(content)
+		})
+	})
+	defer server.Close()
+
+	// Every provided digest must match
+	goodMD5 := fmt.Sprintf("%x", md5.Sum(content))
+	badSHA512 := strings.Repeat("0", 128)
+	cases := []struct {
+		digests string
+		ok      bool
+	}{
+		{fmt.Sprintf(`{"md5": %q}`, goodMD5), true},
+		{fmt.Sprintf(`{"md5": %q, "sha512": %q}`, goodMD5, badSHA512), false},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+		digests = c.digests
+
+		dir := t.TempDir()
+		err := runCommand(cc, []string{"download", "foo@1.0.0", "--output-dir", dir})
+		if c.ok && err != nil {
+			t.Errorf("%s: Unexpected error %s", c.digests, err)
+		} else if !c.ok && (err == nil || !strings.Contains(err.Error(), "Checksum mismatch")) {
+			t.Errorf("%s: Expected checksum mismatch, got %v", c.digests, err)
+		}
+
+		// Nothing but the verified file is left in directory
+		entries, _ := os.ReadDir(dir)
+		if c.ok && (len(entries) != 1 || entries[0].Name() != "foo-1.0.0.tgz") {
+			t.Errorf("%s: Expected only downloaded file, got %v", c.digests, entries)
+		} else if !c.ok && len(entries) != 0 {
+			t.Errorf("%s: Expected no files, got %v", c.digests, entries)
+		}
+	}
+}
+
+func TestDownloadCommandResume(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	content := []byte(strings.Repeat("0123456789", 10))
+	supportsRange := true
+	var rangeHdr string
+
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `[{
+				"id": "ver_a1",
+				"version": "1.0.0",
+				"filename": "foo-1.0.0.tgz",
+				"download_url": "http://%s/files/foo-1.0.0.tgz",
+				"digests": { "md5": "%x" }
+			}]`, r.Host, md5.Sum(content))
+		})
+		mux.HandleFunc("/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+			rangeHdr = r.Header.Get("Range")
+			if supportsRange {
+				http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+			} else {
+				w.Write(content)
+			}
+		})
+	})
+	defer server.Close()
+
+	// Each case is ".part" content, server Range support, and expected Range header
+	cases := []struct {
+		part          string
+		supportsRange bool
+		rangeHdr      string
+	}{
+		{"0123456789012", true, "bytes=13-"},
+		{"garbage", false, "bytes=7-"},
+		{"", true, ""},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+		supportsRange = c.supportsRange
+
+		dir := t.TempDir()
+		path := filepath.Join(dir, "foo-1.0.0.tgz")
+		if c.part != "" {
+			os.WriteFile(path+".part", []byte(c.part), 0600)
+		}
+
+		err := runCommandNoErr(cc, []string{"download", "foo@1.0.0", "--output-dir", dir})
+		if err != nil {
+			t.Fatalf("%q: %s", c.part, err)
+		} else if rangeHdr != c.rangeHdr {
+			t.Errorf("%q: Expected Range %q, got %q", c.part, c.rangeHdr, rangeHdr)
+		}
+
+		if out, err := os.ReadFile(path); err != nil || !bytes.Equal(out, content) {
+			t.Errorf("%q: Expected full content, got %q (%v)", c.part, out, err)
+		} else if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+			t.Errorf("%q: Expected .part file to be removed", c.part)
+		}
+	}
+}
+
+func TestDownloadCommandFileForbidden(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			item := fmt.Sprintf(downloadVersionJSON, "http://"+r.Host, "1.0.0", "null")
+			w.Write([]byte("[" + item + "]"))
+		})
+		mux.HandleFunc("/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(403)
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	dir := t.TempDir()
+	if err := runCommand(cc, []string{"download", "foo@1.0.0", "--output-dir", dir}); err == nil {
+		t.Errorf("Expected download error")
+	}
+
+	// Failed request leaves no empty ".part" file behind
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files, got %v", entries)
+	}
+}
+
+func TestDownloadCommandUnauthorized(t *testing.T) {
+	server := testDownloadServer(t)
+	dir := t.TempDir()
+	args := []string{"download", "foo@1.0.0", "--output-dir", dir}
+	testCommandLoginPreCheck(t, args, server)
+	server.Close()
+}
+
+func TestDownloadCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/versions", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"download", "foo@1.0.0"}, server)
+	server.Close()
+}
+
+func testDownloadServer(t *testing.T) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			q := r.URL.Query()
+			if q.Get("name") != "foo" {
+				t.Errorf("Invalid request: %s", r.URL.String())
+			} else if k := q.Get("kind"); k != "" && k != "js" {
+				t.Errorf("Invalid kind: %q", k)
+			}
+
+			// Filter by version, as API does
+			items := []string{}
+			for _, ver := range downloadVersions {
+				if qv := q.Get("version"); qv == "" || qv == ver {
+					item := fmt.Sprintf(downloadVersionJSON, "http://"+r.Host, ver, downloadPackageJSON)
+					items = append(items, item)
+				}
+			}
+
+			resp := "[" + strings.Join(items, ",") + "]"
+			w.Write([]byte(resp))
+		})
+		mux.HandleFunc("/files/{name}", func(w http.ResponseWriter, r *http.Request) {
+			name := filepath.Base(r.URL.Path)
+			w.Write([]byte("content of " + name))
+		})
+	})
+}
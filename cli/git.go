@@ -3,10 +3,14 @@ package cli
 import (
 	"github.com/gemfury/cli/api"
 	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
 	"github.com/spf13/cobra"
 
+	"context"
 	"fmt"
+	"io"
 	"strings"
+	"text/tabwriter"
 )
 
 // Root for Git subcommands
@@ -16,19 +20,98 @@ func NewCmdGitRoot() *cobra.Command {
 		Short: "Git repository commands",
 	}
 
+	gitCmd.AddCommand(NewCmdGitApply())
 	gitCmd.AddCommand(NewCmdGitConfig())
+	gitCmd.AddCommand(NewCmdGitCredential())
+	gitCmd.AddCommand(NewCmdGitInfo())
 	gitCmd.AddCommand(NewCmdGitDestroy())
 	gitCmd.AddCommand(NewCmdGitRebuild())
 	gitCmd.AddCommand(NewCmdGitRename())
+	gitCmd.AddCommand(NewCmdGitRemote())
 	gitCmd.AddCommand(NewCmdGitStack())
 	gitCmd.AddCommand(NewCmdGitList())
+	gitCmd.AddCommand(NewCmdGitBuilds())
+	gitCmd.AddCommand(NewCmdGitLogs())
 
 	return gitCmd
 }
 
+// NewCmdGitInfo generates the Cobra command for "git:info"
+func NewCmdGitInfo() *cobra.Command {
+	var jsonFlag bool
+
+	infoCmd := &cobra.Command{
+		Use:   "info REPO",
+		Short: "Show details of a Git repository",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a repository")
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			repo, err := c.GitInfo(cc, args[0])
+			if err != nil {
+				return err
+			}
+
+			config, err := c.GitConfig(cc, args[0])
+			if err != nil {
+				return err
+			}
+
+			if jsonFlag {
+				return termPrintJSON(term, struct {
+					*api.GitRepo
+					ConfigVarsCount int `json:"config_vars_count"`
+				}{repo, len(config)})
+			}
+
+			na := func(s string) string {
+				if s == "" {
+					return "N/A"
+				}
+				return s
+			}
+
+			lastPush, lastBuild := "N/A", "N/A"
+			if t := repo.PushedAt; t != nil {
+				lastPush = timeStringWithAgo(*t)
+			}
+			if b := repo.LastBuild; b != nil {
+				lastBuild = fmt.Sprintf("%s (%s, %s)", b.Status, b.ID, timeStringWithAgo(b.CreatedAt))
+			}
+
+			// Print results
+			term.Printf("\n*** %s ***\n\n", repo.Name)
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "id:\t%s\n", repo.ID)
+			fmt.Fprintf(w, "clone_url:\t%s\n", na(repo.CloneURL))
+			fmt.Fprintf(w, "build_stack:\t%s\n", na(repo.Stack.Name))
+			fmt.Fprintf(w, "size:\t%s\n", humanSize(repo.Size))
+			fmt.Fprintf(w, "last_push:\t%s\n", lastPush)
+			fmt.Fprintf(w, "last_build:\t%s\n", lastBuild)
+			fmt.Fprintf(w, "config_vars:\t%d\n", len(config))
+			w.Flush()
+
+			return nil
+		},
+	}
+
+	// Flags and options
+	infoCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON")
+
+	return infoCmd
+}
+
 // NewCmdGitDestroy generates the Cobra command for "git:destroy"
 func NewCmdGitDestroy() *cobra.Command {
-	var resetOnly bool
+	var resetOnly, forceFlag, dryRunFlag bool
 
 	destroyCmd := &cobra.Command{
 		Use:     "destroy REPO",
@@ -52,6 +135,16 @@ func NewCmdGitDestroy() *cobra.Command {
 				return err
 			}
 
+			action := "permanently remove repository " + args[0]
+			if resetOnly {
+				action = "reset content of repository " + args[0] + ", keeping its configuration and build history"
+			}
+
+			ok, err := confirmGitRepoAction(cmd, c, args[0], action, forceFlag, dryRunFlag)
+			if !ok {
+				return err
+			}
+
 			err = c.GitDestroy(cc, args[0], resetOnly)
 			if err != nil {
 				return err
@@ -69,12 +162,16 @@ func NewCmdGitDestroy() *cobra.Command {
 
 	// Flags and options
 	destroyCmd.Flags().BoolVar(&resetOnly, "reset-only", false, "Reset repo without destroying")
+	destroyCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+	destroyCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would happen")
 
 	return destroyCmd
 }
 
 // NewCmdGitRename generates the Cobra command for "git:reset"
 func NewCmdGitRename() *cobra.Command {
+	var forceFlag, dryRunFlag bool
+
 	renameCmd := &cobra.Command{
 		Use:   "rename REPO NEWNAME",
 		Short: "Rename a Git repository",
@@ -91,6 +188,12 @@ func NewCmdGitRename() *cobra.Command {
 				return err
 			}
 
+			action := fmt.Sprintf("rename repository %s to %s", args[0], args[1])
+			ok, err := confirmGitRepoAction(cmd, c, args[0], action, forceFlag, dryRunFlag)
+			if !ok {
+				return err
+			}
+
 			err = c.GitRename(cc, args[0], args[1])
 			if err != nil {
 				return err
@@ -101,19 +204,86 @@ func NewCmdGitRename() *cobra.Command {
 		},
 	}
 
+	// Flags and options
+	renameCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+	renameCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would happen")
+
 	return renameCmd
 }
 
+// Describes the repository and action, then asks to type its name to confirm.
+// Without a terminal to prompt, destructive actions require "--force".
+func confirmGitRepoAction(cmd *cobra.Command, c *api.Client, repo, action string, force, dryRun bool) (bool, error) {
+	if force && !dryRun {
+		return true, nil
+	}
+
+	cc := cmd.Context()
+	term := ctx.Terminal(cc)
+
+	info, err := c.GitInfo(cc, repo)
+	if err != nil {
+		return false, err
+	}
+
+	term.Printf("This will %s:\n\n", action)
+	w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "  id:\t%s\n", info.ID)
+	fmt.Fprintf(w, "  build_stack:\t%s\n", info.Stack.Name)
+	fmt.Fprintf(w, "  size:\t%s\n", humanSize(info.Size))
+	if info.PushedAt != nil {
+		fmt.Fprintf(w, "  last_push:\t%s\n", timeStringWithAgo(*info.PushedAt))
+	}
+	w.Flush()
+	term.Println()
+
+	if dryRun {
+		term.Println("Dry run, no changes made")
+		return false, nil
+	} else if !term.IsInteractive() {
+		cmd.SilenceUsage = true
+		return false, fmt.Errorf("Refusing to continue without a terminal. Use --force to skip confirmation")
+	}
+
+	label := fmt.Sprintf("Type %q to confirm", info.Name)
+	if ok, err := terminal.PromptTyped(term, label, info.Name); err != nil {
+		return false, err
+	} else if !ok {
+		term.Println("Repository name did not match, nothing changed")
+		return false, nil
+	}
+
+	return true, nil
+}
+
 // NewCmdGitConfigSet sets build configuration keys
 func NewCmdGitRebuild() *cobra.Command {
 	var revisionFlag string
+	var batch gitBatchFlags
 
 	rebuildCmd := &cobra.Command{
 		Use:   "rebuild REPO",
 		Short: "Run the builder on the repo",
+		Long: `Run the builder on the repo, and stream its output.
+
+With --all or --match, omit REPO to rebuild many repos at once.
+Build output is not shown, only the result for each repo.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			term := ctx.Terminal(cmd.Context())
 
+			if batch.enabled() {
+				if len(args) != 0 || revisionFlag != "" {
+					return fmt.Errorf("Repository and revision can't be used with --all or --match")
+				}
+				return gitBatchRun(cmd, &batch, func(cc context.Context, c *api.Client, repo string) error {
+					buildID, err := c.GitRebuild(cc, io.Discard, repo, "")
+					if err != nil {
+						return err
+					}
+					return gitBuildResult(cc, c, repo, buildID)
+				})
+			}
+
 			if len(args) != 1 {
 				return fmt.Errorf("Please specify a repository")
 			}
@@ -138,21 +308,48 @@ func NewCmdGitRebuild() *cobra.Command {
 			msg = msg + " ...\n"
 
 			term.Printf(msg)
-			err = c.GitRebuild(cc, term.IOOut(), repo, rev)
+			buildID, err := c.GitRebuild(cc, term.IOOut(), repo, rev)
 			if err != nil {
 				return err
 			}
 
+			// Exit status reflects the result of the build
+			if err := gitBuildResult(cc, c, repo, buildID); err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+
 			return nil
 		},
 	}
 
 	// Flags and options
 	rebuildCmd.Flags().StringVarP(&revisionFlag, "revision", "r", "", "Revision")
+	batch.register(rebuildCmd)
 
 	return rebuildCmd
 }
 
+// Error with build-failed exit code, if the triggered build of repo failed.
+// Other builds, such as ones from a concurrent push, are not considered.
+// Without a build ID, the result is unknown, so only a warning is shown.
+func gitBuildResult(cc context.Context, c *api.Client, repo, buildID string) error {
+	if buildID == "" {
+		term := ctx.Terminal(cc)
+		fmt.Fprintf(term.IOErr(), "WARNING: Unable to determine result of %s build\n", repo)
+		return nil
+	}
+
+	build, err := c.GitBuildInfo(cc, repo, buildID)
+	if err != nil {
+		return err
+	} else if build.Failed() {
+		err := fmt.Errorf("Build %s %s", build.ID, build.Status)
+		return exitError{err, exitCodeBuildFailed}
+	}
+	return nil
+}
+
 // NewCmdGitConfigSet lists Git repositories
 func NewCmdGitList() *cobra.Command {
 	return &cobra.Command{
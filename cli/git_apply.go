@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"context"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// NewCmdGitApply syncs repositories to the state described in a YAML file
+func NewCmdGitApply() *cobra.Command {
+	var dryRunFlag, forceFlag bool
+
+	applyCmd := &cobra.Command{
+		Use:   "apply FILE",
+		Short: "Sync Git repos to configuration in YAML file",
+		Long: `Sync Git repos to configuration in YAML file:
+
+  repos:
+    - repo: api-server
+      name: api           # Rename repository (optional)
+      stack: fury-22      # Build stack (optional)
+      prune: true         # Remove config vars missing below
+      config:
+        RAILS_ENV: production
+
+With --dry-run, changes are shown without applying them, and the
+command exits with code 3 when any repository has drifted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a configuration file")
+			}
+
+			repos, err := readGitApplyFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			plans, err := gitApplyPlans(cc, c, repos)
+			if err != nil {
+				return err
+			}
+
+			drifted := 0
+			for _, p := range plans {
+				if p.Len() > 0 {
+					termPrintGitApplyPlan(term, p)
+					drifted++
+				}
+			}
+
+			if drifted == 0 {
+				term.Println("All repositories are up to date")
+				return nil
+			} else if dryRunFlag {
+				cmd.SilenceUsage = true
+				err := fmt.Errorf("Drift detected in %d of %d repositories", drifted, len(plans))
+				return exitError{err, exitCodeDrift}
+			}
+
+			if !forceFlag {
+				confirm := fmt.Sprintf("Apply changes to %d repositories? [y/N]", drifted)
+				if ok, err := terminal.PromptConfirm(term, confirm); !ok {
+					return err
+				}
+			}
+
+			var multiErr *multierror.Error
+			for _, p := range plans {
+				if p.Len() == 0 {
+					continue
+				} else if err := p.Apply(cc, c); err != nil {
+					multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", p.repo, err))
+					continue
+				}
+				term.Printf("Updated %s repository\n", p.Name())
+			}
+
+			return multiErr.ErrorOrNil()
+		},
+	}
+
+	// Flags and options
+	applyCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show changes, exit non-zero on drift")
+	applyCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+
+	return applyCmd
+}
+
+// gitApplyRepo is the desired state of a repository for "git apply".
+// Empty fields, and missing config, leave that setting unmanaged.
+type gitApplyRepo struct {
+	Repo   string            `yaml:"repo"`
+	Name   string            `yaml:"name"`
+	Stack  string            `yaml:"stack"`
+	Prune  bool              `yaml:"prune"`
+	Config map[string]string `yaml:"config"`
+}
+
+// gitApplyFile is the YAML file for "git apply"
+type gitApplyFile struct {
+	Repos []gitApplyRepo `yaml:"repos"`
+}
+
+func readGitApplyFile(path string) ([]gitApplyRepo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := gitApplyFile{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("Invalid file %q: %w", path, err)
+	} else if len(file.Repos) == 0 {
+		return nil, fmt.Errorf("No repos listed in %q", path)
+	}
+
+	seen := map[string]bool{}
+	for i, r := range file.Repos {
+		if r.Repo == "" {
+			return nil, fmt.Errorf("Entry #%d has no repo", i+1)
+		} else if seen[r.Repo] {
+			return nil, fmt.Errorf("Repo %q is listed more than once", r.Repo)
+		}
+		seen[r.Repo] = true
+	}
+
+	return file.Repos, nil
+}
+
+// gitApplyPlan is the set of changes to reach desired state of one repo
+type gitApplyPlan struct {
+	repo    string
+	stack   [2]string // Current and desired stack
+	rename  string
+	current []api.GitConfigPair
+	config  map[string]*string
+}
+
+// Len is the number of changes in the plan
+func (p *gitApplyPlan) Len() int {
+	n := len(p.config)
+	if p.stack[1] != "" {
+		n++
+	}
+	if p.rename != "" {
+		n++
+	}
+	return n
+}
+
+// Name of the repository once the plan is applied
+func (p *gitApplyPlan) Name() string {
+	if p.rename != "" {
+		return p.rename
+	}
+	return p.repo
+}
+
+// Apply renames last, so that earlier updates use the current name
+func (p *gitApplyPlan) Apply(cc context.Context, c *api.Client) error {
+	if len(p.config) > 0 {
+		if err := c.GitConfigSet(cc, p.repo, p.config); err != nil {
+			return err
+		}
+	}
+
+	if stack := p.stack[1]; stack != "" {
+		if err := c.GitStackSet(cc, p.repo, stack); err != nil {
+			return err
+		}
+	}
+
+	if p.rename != "" {
+		return c.GitRename(cc, p.repo, p.rename)
+	}
+
+	return nil
+}
+
+// Compare desired state with each repository to build plans
+func gitApplyPlans(cc context.Context, c *api.Client, repos []gitApplyRepo) ([]*gitApplyPlan, error) {
+	stacks, err := c.GitStacks(cc)
+	if err != nil {
+		return nil, err
+	}
+
+	plans := make([]*gitApplyPlan, 0, len(repos))
+	for _, r := range repos {
+		if r.Stack != "" {
+			if _, err := validGitStack(stacks, r.Stack); err != nil {
+				return nil, fmt.Errorf("%s: %w", r.Repo, err)
+			}
+		}
+
+		info, err := c.GitInfo(cc, r.Repo)
+
+		// Repo already renamed by a previous run
+		if errors.Is(err, api.ErrNotFound) && r.Name != "" {
+			info, err = c.GitInfo(cc, r.Name)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", r.Repo, err)
+		}
+
+		plan := &gitApplyPlan{repo: info.Name}
+		if r.Name != "" && r.Name != info.Name {
+			plan.rename = r.Name
+		}
+		if r.Stack != "" && r.Stack != info.Stack.Name {
+			plan.stack = [2]string{info.Stack.Name, r.Stack}
+		}
+
+		if r.Config != nil || r.Prune {
+			plan.current, err = c.GitConfig(cc, info.Name)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", r.Repo, err)
+			}
+			plan.config = gitConfigChanges(plan.current, r.Config, r.Prune)
+		}
+
+		plans = append(plans, plan)
+	}
+
+	return plans, nil
+}
+
+func termPrintGitApplyPlan(term terminal.Terminal, p *gitApplyPlan) {
+	term.Printf("%s:\n", p.repo)
+	if p.rename != "" {
+		term.Printf("  name: %s => %s\n", p.repo, p.rename)
+	}
+	if s := p.stack; s[1] != "" {
+		term.Printf("  stack: %s => %s\n", s[0], s[1])
+	}
+	termPrintGitConfigChanges(term, "  ", p.current, p.config)
+}
@@ -0,0 +1,162 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const gitApplyYAML = `repos:
+  - repo: repo-name
+    name: new-name
+    stack: fury-22
+    prune: true
+    config:
+      KEY2: VALUE2
+      KEY3: added
+`
+
+// ==== GIT APPLY ====
+
+func TestGitApplyCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	requests := []string{}
+	server := testGitApplyServer(t, &requests)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	path := testGitApplyFile(t, gitApplyYAML)
+	err := runCommandNoErr(cc, []string{"git", "apply", path, "--force"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "repo-name:\n  name: repo-name => new-name\n  stack: fury-14 => fury-22\n" +
+		"  - KEY1\n  + KEY3\nUpdated new-name repository\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// Rename must come last, after updates to the old name
+	expReqs := []string{
+		`PATCH /git/repos/me/repo-name/config-vars {"KEY1":null,"KEY3":"added"}`,
+		"PATCH /git/repos/me/repo-name?repo[build_stack]=fury-22",
+		"PATCH /git/repos/me/repo-name?repo[name]=new-name",
+	}
+	if got := strings.Join(requests, "\n"); got != strings.Join(expReqs, "\n") {
+		t.Errorf("Expected requests:\n%s\ngot:\n%s", strings.Join(expReqs, "\n"), got)
+	}
+}
+
+func TestGitApplyCommandDryRun(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	requests := []string{}
+	server := testGitApplyServer(t, &requests)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Drift exits non-zero without applying changes
+	path := testGitApplyFile(t, gitApplyYAML)
+	err := runCommand(cc, []string{"git", "apply", path, "--dry-run"})
+	if err == nil || cli.ExitCode(err) != 3 {
+		t.Fatalf("Expected drift exit code 3, got %v", err)
+	} else if len(requests) > 0 {
+		t.Errorf("Expected no changes, got %q", requests)
+	}
+
+	// No drift when repo matches
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, auth)
+	ctx.GlobalFlags(cc).Endpoint = server.URL
+
+	path = testGitApplyFile(t, "repos:\n- repo: repo-name\n  stack: fury-14\n  config: { KEY1: VALUE1 }\n")
+	err = runCommandNoErr(cc, []string{"git", "apply", path, "--dry-run"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "All repositories are up to date\n"
+	if outStr := string(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+
+	// Unknown stack is rejected
+	path = testGitApplyFile(t, "repos:\n- repo: repo-name\n  stack: fury-99\n")
+	err = runCommand(cc, []string{"git", "apply", path, "--dry-run"})
+	if err == nil || !strings.Contains(err.Error(), `Unknown build stack "fury-99"`) {
+		t.Errorf("Expected unknown stack error, got %v", err)
+	}
+}
+
+func TestGitApplyCommandUnauthorized(t *testing.T) {
+	path := testGitApplyFile(t, gitApplyYAML)
+	server := testGitApplyServer(t, &[]string{})
+	testCommandLoginPreCheck(t, []string{"git", "apply", path, "-f"}, server)
+	server.Close()
+}
+
+func TestGitApplyCommandForbidden(t *testing.T) {
+	path := testGitApplyFile(t, gitApplyYAML)
+	server := testutil.APIServer(t, "GET", "/git/stacks", "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "apply", path, "-f"}, server)
+	server.Close()
+}
+
+func testGitApplyFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "fury-git.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Serves repo info, config, and stacks, and records PATCH requests
+func testGitApplyServer(t *testing.T, requests *[]string) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/stacks", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(gitStacksResponse))
+		})
+		mux.HandleFunc("/git/repos/me/repo-name", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			if r.Method == "PATCH" {
+				*requests = append(*requests, r.Method+" "+r.URL.String())
+				w.Write([]byte("{}"))
+			} else {
+				w.Write([]byte(gitInfoResponse))
+			}
+		})
+		mux.HandleFunc("/git/repos/me/repo-name/config-vars", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			if r.Method == "PATCH" {
+				body := struct {
+					ConfigVars json.RawMessage `json:"config_vars"`
+				}{}
+				json.NewDecoder(r.Body).Decode(&body)
+				*requests = append(*requests, r.Method+" "+r.URL.String()+" "+string(body.ConfigVars))
+				w.Write([]byte("{}"))
+			} else {
+				w.Write([]byte(gitConfigResponse))
+			}
+		})
+	})
+}
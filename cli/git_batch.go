@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+)
+
+// Number of repositories updated at the same time in batch mode
+const gitBatchConcurrency = 4
+
+// gitBatchFlags select many repos, instead of a REPO argument
+type gitBatchFlags struct {
+	all   bool
+	match string
+}
+
+func (f *gitBatchFlags) register(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&f.all, "all", false, "Run for all repos in account")
+	cmd.Flags().StringVar(&f.match, "match", "", "Run for repos matching pattern, such as 'svc-*'")
+}
+
+// Enabled when either flag is present. REPO argument is then omitted
+func (f *gitBatchFlags) enabled() bool {
+	return f.all || f.match != ""
+}
+
+// Number of leading REPO arguments expected by the command
+func (f *gitBatchFlags) repoArgs() int {
+	if f.enabled() {
+		return 0
+	}
+	return 1
+}
+
+// Runs fn for each selected repo with bounded concurrency, then prints
+// a result per repo and returns the combined errors
+func gitBatchRun(cmd *cobra.Command, f *gitBatchFlags, fn func(context.Context, *api.Client, string) error) error {
+	if f.all && f.match != "" {
+		return fmt.Errorf("Please use either --all or --match")
+	} else if _, err := path.Match(f.match, ""); err != nil {
+		return fmt.Errorf("Invalid pattern %q: %w", f.match, err)
+	}
+
+	cc := cmd.Context()
+	term := ctx.Terminal(cc)
+	c, err := newAPIClient(cc)
+	if err != nil {
+		return err
+	}
+
+	repos, err := gitBatchRepos(cc, c, f.match)
+	if err != nil {
+		return err
+	} else if len(repos) == 0 && f.all {
+		return fmt.Errorf("No Git repositories found in this account")
+	} else if len(repos) == 0 {
+		return fmt.Errorf("No Git repositories found matching %q", f.match)
+	}
+
+	errs := make([]error, len(repos))
+	sem := make(chan struct{}, gitBatchConcurrency)
+	var wg sync.WaitGroup
+
+	for i, repo := range repos {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			errs[i] = fn(cc, c, repo)
+		}()
+	}
+
+	wg.Wait()
+
+	var multiErr *multierror.Error
+	for i, repo := range repos {
+		err := errs[i]
+		if err == nil {
+			term.Printf("%s - done\n", repo)
+			continue
+		}
+
+		multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", repo, err))
+		if errors.Is(err, api.ErrForbidden) {
+			term.Printf("%s - no permission\n", repo)
+		} else if ue, ok := err.(api.UserError); ok {
+			term.Printf("%s - %s\n", repo, ue.ShortError())
+		} else {
+			term.Printf("%s - error %q\n", repo, err.Error())
+		}
+	}
+
+	if multiErr != nil {
+		cmd.SilenceUsage = true
+		multiErr.ErrorFormat = func(errs []error) string {
+			return fmt.Sprintf("Failed for %d of %d repositories", len(errs), len(repos))
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+// Names of all repositories in account, optionally matching a glob pattern
+func gitBatchRepos(cc context.Context, c *api.Client, pattern string) ([]string, error) {
+	repos := []string{}
+
+	err := iterateAll(cc, false, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+		resp, err := c.GitList(cc, pageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range resp.Root.Repos {
+			if ok, _ := path.Match(pattern, r.Name); ok || pattern == "" {
+				repos = append(repos, r.Name)
+			}
+		}
+
+		return resp.Pagination, nil
+	})
+
+	return repos, err
+}
@@ -0,0 +1,160 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var gitBatchReposResponses = []string{`{ "repos": [
+	{ "name": "svc-api" },
+	{ "name": "web" }
+]}`, `{ "repos": [
+	{ "name": "svc-worker" }
+]}`}
+
+// ==== GIT BATCH (--all / --match) ====
+
+func TestGitBatchCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	// Each case is args, expected API updates, and output
+	cases := []struct {
+		args     []string
+		requests string
+		out      string
+	}{{
+		[]string{"config", "set", "--all", "KEY=VAL"},
+		"PATCH svc-api, PATCH svc-worker, PATCH web",
+		"svc-api - done\nweb - done\nsvc-worker - done\n",
+	}, {
+		[]string{"config", "unset", "--match", "svc-*", "KEY"},
+		"PATCH svc-api, PATCH svc-worker",
+		"svc-api - done\nsvc-worker - done\n",
+	}, {
+		[]string{"stack", "set", "--match", "web", "--latest"},
+		"PATCH web?repo[build_stack]=fury-22",
+		"web - done\n",
+	}, {
+		[]string{"rebuild", "--match", "*-worker"},
+		"POST svc-worker",
+		"svc-worker - done\n",
+	}}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		requests := []string{}
+		server := testGitBatchServer(t, &requests, "success")
+
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommandNoErr(cc, append([]string{"git"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		sort.Strings(requests)
+		if r := strings.Join(requests, ", "); r != c.requests {
+			t.Errorf("%v: Expected requests %q, got %q", c.args, c.requests, r)
+		}
+
+		if outStr := string(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
+
+		server.Close()
+	}
+}
+
+func TestGitBatchCommandFailure(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitBatchServer(t, &[]string{}, "failed")
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Failed builds are reported per repo, with build-failed exit code
+	err := runCommand(cc, []string{"git", "rebuild", "--match", "svc-*"})
+	if err == nil || err.Error() != "Failed for 2 of 2 repositories" {
+		t.Fatalf("Expected batch error, got %v", err)
+	} else if code := cli.ExitCode(err); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+
+	exp := "svc-api - error \"Build bld_d4e5f6 failed\"\nsvc-worker - error \"Build bld_d4e5f6 failed\"\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// Pattern without matches
+	err = runCommand(cc, []string{"git", "config", "set", "--match", "nope-*", "KEY=VAL"})
+	if err == nil || !strings.Contains(err.Error(), `matching "nope-*"`) {
+		t.Errorf("Expected no match error, got %v", err)
+	}
+}
+
+func TestGitBatchCommandUnauthorized(t *testing.T) {
+	server := testGitBatchServer(t, &[]string{}, "success")
+	testCommandLoginPreCheck(t, []string{"git", "config", "set", "--all", "KEY=VAL"}, server)
+	server.Close()
+}
+
+func TestGitBatchCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/git/repos/me", "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "config", "set", "--all", "KEY=VAL"}, server)
+	server.Close()
+}
+
+// Serves a paginated repo listing, and records updates to each repo
+func testGitBatchServer(t *testing.T, requests *[]string, buildStatus string) *httptest.Server {
+	var mu sync.Mutex
+	record := func(r *http.Request, s string) {
+		mu.Lock()
+		defer mu.Unlock()
+		*requests = append(*requests, r.Method+" "+s)
+	}
+
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			testutil.APIPaginatedResponse(t, w, r, gitBatchReposResponses, 200)
+		})
+		mux.HandleFunc("/git/stacks", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(gitStacksResponse))
+		})
+		mux.HandleFunc("/git/repos/me/{repo}", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			record(r, r.PathValue("repo")+"?"+r.URL.RawQuery)
+			w.Write([]byte("{}"))
+		})
+		mux.HandleFunc("/git/repos/me/{repo}/config-vars", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			record(r, r.PathValue("repo"))
+			w.Write([]byte("{}"))
+		})
+		mux.HandleFunc("/git/repos/me/{repo}/builds", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			record(r, r.PathValue("repo"))
+			w.Header().Set("X-Build-Id", "bld_d4e5f6")
+			w.Write([]byte("Build output\n"))
+		})
+		mux.HandleFunc("/git/repos/me/{repo}/builds/{build}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(gitBuildInfoResponse, buildStatus)))
+		})
+	})
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+)
+
+// NewCmdGitBuilds lists build history for a repository
+func NewCmdGitBuilds() *cobra.Command {
+	var limitFlag int
+
+	buildsCmd := &cobra.Command{
+		Use:   "builds REPO",
+		Short: "List recent builds of a repo",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a repository")
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			pageReq := api.PaginationRequest{Limit: limitFlag}
+			resp, err := c.GitBuilds(cc, args[0], &pageReq)
+			if err != nil {
+				return err
+			}
+
+			builds := resp.Root.Builds
+			if len(builds) == 0 {
+				term.Println("No builds found for this repository")
+				return nil
+			}
+
+			// Print results
+			term.Printf("\n*** %s builds ***\n\n", args[0])
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "id\trevision\tstatus\tduration\ttriggered_by\tstarted_at\n")
+
+			for _, b := range builds {
+				duration := "running"
+				if b.FinishedAt != nil {
+					duration = b.Duration().Round(time.Second).String()
+				}
+
+				startedAt := timeStringWithAgo(b.CreatedAt)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", b.ID, b.Revision, b.Status, duration, b.DisplayCreatedBy(), startedAt)
+			}
+
+			w.Flush()
+			return nil
+		},
+	}
+
+	// Flags and options
+	buildsCmd.Flags().IntVar(&limitFlag, "limit", 20, "Number of builds to show")
+
+	return buildsCmd
+}
+
+// NewCmdGitLogs replays or follows the log of a build
+func NewCmdGitLogs() *cobra.Command {
+	var followFlag bool
+
+	logsCmd := &cobra.Command{
+		Use:   "logs REPO [BUILD]",
+		Short: "Show log of a build (latest by default)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 || len(args) > 2 {
+				return fmt.Errorf("Please specify a repository and optional build")
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			repo, buildID := args[0], ""
+			if len(args) == 2 {
+				buildID = args[1]
+			} else if build, err := gitLatestBuild(cc, c, repo); err != nil {
+				return err
+			} else if build == nil {
+				term.Println("No builds found for this repository")
+				return nil
+			} else {
+				buildID = build.ID
+			}
+
+			return c.GitBuildLog(cc, term.IOOut(), repo, buildID, followFlag)
+		},
+	}
+
+	// Flags and options
+	logsCmd.Flags().BoolVarP(&followFlag, "follow", "f", false, "Stream log until build finishes")
+
+	return logsCmd
+}
+
+// Most recent build of a repository, or nil if it has never been built
+func gitLatestBuild(cc context.Context, c *api.Client, repo string) (*api.GitBuild, error) {
+	resp, err := c.GitBuilds(cc, repo, &api.PaginationRequest{Limit: 1})
+	if err != nil {
+		return nil, err
+	} else if builds := resp.Root.Builds; len(builds) > 0 {
+		return builds[0], nil
+	}
+	return nil, nil
+}
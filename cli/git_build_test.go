@@ -0,0 +1,116 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const gitBuildLogResponse = "-----> Building\n-----> Done\n"
+
+// ==== GIT BUILDS ====
+
+func TestGitBuildsCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitBuildServer(t)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"git", "builds", "repo-name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "bld_a1b2c3 9f8e7d6 success 1m30s user1 2011-05-26 17:39"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+}
+
+func TestGitBuildsCommandUnauthorized(t *testing.T) {
+	server := testGitBuildServer(t)
+	testCommandLoginPreCheck(t, []string{"git", "builds", "repo-name"}, server)
+	server.Close()
+}
+
+func TestGitBuildsCommandForbidden(t *testing.T) {
+	path := "/git/repos/me/repo-name/builds"
+	server := testutil.APIServer(t, "GET", path, "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "builds", "repo-name"}, server)
+	server.Close()
+}
+
+// ==== GIT LOGS ====
+
+func TestGitLogsCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitBuildServer(t)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Latest build, replayed
+	err := runCommandNoErr(cc, []string{"git", "logs", "repo-name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "follow=false\n" + gitBuildLogResponse
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// Specific build, followed
+	err = runCommandNoErr(cc, []string{"git", "logs", "repo-name", "bld_a1b2c3", "--follow"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp = "follow=true\n" + gitBuildLogResponse
+	if outStr := string(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+}
+
+func TestGitLogsCommandUnauthorized(t *testing.T) {
+	server := testGitBuildServer(t)
+	testCommandLoginPreCheck(t, []string{"git", "logs", "repo-name"}, server)
+	server.Close()
+}
+
+func TestGitLogsCommandForbidden(t *testing.T) {
+	path := "/git/repos/me/repo-name/builds"
+	server := testutil.APIServer(t, "GET", path, "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "logs", "repo-name"}, server)
+	server.Close()
+}
+
+func testGitBuildServer(t *testing.T) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/repo-name/builds", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(fmt.Sprintf(gitBuildsResponse, "success")))
+		})
+		mux.HandleFunc("/git/repos/me/repo-name/builds/bld_a1b2c3/log", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			follow := r.URL.Query().Get("follow") == "1"
+			fmt.Fprintf(w, "follow=%t\n%s", follow, gitBuildLogResponse)
+		})
+	})
+}
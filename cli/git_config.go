@@ -3,27 +3,41 @@ package cli
 import (
 	"github.com/gemfury/cli/api"
 	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
 	"github.com/spf13/cobra"
 	"sort"
 	"strings"
 	"text/tabwriter"
 
+	"context"
 	"fmt"
+	"os"
 )
 
+// Placeholder for config values that are not revealed
+const maskedConfigValue = "********"
+
 // NewCmdGitConfig is the root for Git Config
 func NewCmdGitConfig() *cobra.Command {
+	var revealFlag bool
+
 	gitConfigCmd := &cobra.Command{
 		Use:   "config REPO",
 		Short: "Configure Git build",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return filteredGitConfig(cmd, args, false)
+			return filteredGitConfig(cmd, args, false, revealFlag)
 		},
 	}
 
+	// Flags and options
+	gitConfigCmd.Flags().BoolVar(&revealFlag, "reveal", false, "Show configuration values")
+
 	gitConfigCmd.AddCommand(NewCmdGitConfigSet())
 	gitConfigCmd.AddCommand(NewCmdGitConfigGet())
 	gitConfigCmd.AddCommand(NewCmdGitConfigUnset())
+	gitConfigCmd.AddCommand(NewCmdGitConfigExport())
+	gitConfigCmd.AddCommand(NewCmdGitConfigImport())
+	gitConfigCmd.AddCommand(NewCmdGitConfigCopy())
 
 	return gitConfigCmd
 }
@@ -34,7 +48,7 @@ func NewCmdGitConfigGet() *cobra.Command {
 		Use:   "get REPO KEY",
 		Short: "Get Git build environment key",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return filteredGitConfig(cmd, args, true)
+			return filteredGitConfig(cmd, args, true, true)
 		},
 	}
 
@@ -42,7 +56,7 @@ func NewCmdGitConfigGet() *cobra.Command {
 }
 
 // Filtered/unfiltered retrieval of Git Config for commands above
-func filteredGitConfig(cmd *cobra.Command, args []string, filter bool) error {
+func filteredGitConfig(cmd *cobra.Command, args []string, filter, reveal bool) error {
 	if filter && len(args) < 2 {
 		return fmt.Errorf("Please specify a repository and a key")
 	} else if !filter && len(args) != 1 {
@@ -84,6 +98,9 @@ func filteredGitConfig(cmd *cobra.Command, args []string, filter bool) error {
 	w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
 
 	for _, c := range filteredConfig {
+		if !reveal {
+			c.Value = maskedConfigValue
+		}
 		fmt.Fprintf(w, "%s:\t%s\n", c.Key, c.Value)
 	}
 
@@ -93,49 +110,126 @@ func filteredGitConfig(cmd *cobra.Command, args []string, filter bool) error {
 
 // NewCmdGitConfigSet updates one or more configuration keys
 func NewCmdGitConfigSet() *cobra.Command {
+	var fromFileFlag string
+	var stdinFlag bool
+	var batch gitBatchFlags
+
 	gitConfigSetCmd := &cobra.Command{
 		Use:   "set REPO KEY=VAL",
 		Short: "Set Git build environment key",
+		Long: `Set Git build environment keys as KEY=VAL arguments.
+
+To keep secrets out of shell history, set a single KEY with its
+value read from a file (--from-file) or from stdin (--stdin).
+Either way, a single trailing newline is removed from the value.
+
+With --all or --match, omit REPO to update many repos at once.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 2 {
+			n := batch.repoArgs()
+			if len(args) < n+1 {
 				return fmt.Errorf("Please specify a repository and a KEY=VALUE")
 			}
 
-			vars := map[string]*string{}
-			for _, pairStr := range args[1:] {
-				pair := strings.SplitN(pairStr, "=", 2)
-				if len(pair) != 2 {
-					return fmt.Errorf("Argument has no value: %s", pairStr)
-				}
-				vars[pair[0]] = &pair[1]
+			var vars map[string]*string
+			var err error
+			if fromFileFlag != "" && stdinFlag {
+				return fmt.Errorf("Value is read from one source, so --from-file can't be used with --stdin")
+			} else if fromFileFlag != "" || stdinFlag {
+				vars, err = gitConfigSecretVar(cmd, args[n:], fromFileFlag)
+			} else {
+				vars, err = gitConfigVars(args[n:])
+			}
+
+			if err != nil {
+				return err
+			} else if batch.enabled() {
+				return gitBatchRun(cmd, &batch, func(cc context.Context, c *api.Client, repo string) error {
+					return c.GitConfigSet(cc, repo, vars)
+				})
 			}
 
 			return gitConfigUpdate(cmd, args[0], vars)
 		},
 	}
 
+	// Flags and options
+	gitConfigSetCmd.Flags().StringVar(&fromFileFlag, "from-file", "", "Read value of KEY from file")
+	gitConfigSetCmd.Flags().BoolVar(&stdinFlag, "stdin", false, "Read value of KEY from stdin")
+	batch.register(gitConfigSetCmd)
+
 	return gitConfigSetCmd
 }
 
+// Parse KEY=VAL arguments
+func gitConfigVars(args []string) (map[string]*string, error) {
+	vars := map[string]*string{}
+	for _, pairStr := range args {
+		pair := strings.SplitN(pairStr, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("Argument has no value: %s", pairStr)
+		}
+		vars[pair[0]] = &pair[1]
+	}
+	return vars, nil
+}
+
+// Single key with value from file or stdin, never from arguments
+func gitConfigSecretVar(cmd *cobra.Command, args []string, fromFile string) (map[string]*string, error) {
+	if len(args) != 1 || strings.Contains(args[0], "=") {
+		return nil, fmt.Errorf("Please specify a repository and a KEY without value")
+	}
+
+	var value string
+	if fromFile != "" {
+		data, err := os.ReadFile(fromFile)
+		if err != nil {
+			return nil, err
+		}
+		value = strings.TrimSuffix(string(data), "\n")
+		value = strings.TrimSuffix(value, "\r")
+	} else {
+		term := ctx.Terminal(cmd.Context())
+		secret, err := terminal.ReadSecret(term, args[0]+": ")
+		if err != nil {
+			return nil, err
+		}
+		value = secret
+	}
+
+	return map[string]*string{args[0]: &value}, nil
+}
+
 // NewCmdGitConfigSet updates one or more configuration keys
 func NewCmdGitConfigUnset() *cobra.Command {
+	var batch gitBatchFlags
+
 	gitConfigUnsetCmd := &cobra.Command{
 		Use:   "unset REPO KEY",
 		Short: "Remove Git build environment key",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 2 {
+			n := batch.repoArgs()
+			if len(args) < n+1 {
 				return fmt.Errorf("Please specify a repository and a KEY")
 			}
 
 			vars := map[string]*string{}
-			for _, key := range args[1:] {
+			for _, key := range args[n:] {
 				vars[key] = nil
 			}
 
+			if batch.enabled() {
+				return gitBatchRun(cmd, &batch, func(cc context.Context, c *api.Client, repo string) error {
+					return c.GitConfigSet(cc, repo, vars)
+				})
+			}
+
 			return gitConfigUpdate(cmd, args[0], vars)
 		},
 	}
 
+	// Flags and options
+	batch.register(gitConfigUnsetCmd)
+
 	return gitConfigUnsetCmd
 }
 
@@ -0,0 +1,223 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/spf13/cobra"
+
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// NewCmdGitConfigExport prints configuration as a dotenv file
+func NewCmdGitConfigExport() *cobra.Command {
+	exportCmd := &cobra.Command{
+		Use:   "export REPO",
+		Short: "Export Git build environment as .env",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Command requires only a repository")
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			config, err := c.GitConfig(cc, args[0])
+			if err != nil {
+				return err
+			}
+
+			sort.Slice(config, func(i, j int) bool {
+				return config[i].Key < config[j].Key
+			})
+
+			for _, pair := range config {
+				term.Println(formatDotenv(pair.Key, pair.Value))
+			}
+
+			return nil
+		},
+	}
+
+	return exportCmd
+}
+
+// NewCmdGitConfigImport updates configuration from a dotenv file
+func NewCmdGitConfigImport() *cobra.Command {
+	var pruneFlag, forceFlag bool
+
+	importCmd := &cobra.Command{
+		Use:   "import REPO FILE",
+		Short: "Import Git build environment from .env",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("Please specify a repository and a file (or - for stdin)")
+			}
+
+			// Confirmation can't be read once stdin is consumed
+			if args[1] == "-" && !forceFlag {
+				return fmt.Errorf("Reading from stdin requires --force")
+			}
+
+			cc := cmd.Context()
+			var in io.Reader = ctx.Terminal(cc).IOIn()
+			if path := args[1]; path != "-" {
+				file, err := os.Open(path)
+				if err != nil {
+					return err
+				}
+				defer file.Close()
+				in = file
+			}
+
+			desired, err := parseDotenv(in)
+			if err != nil {
+				return err
+			}
+
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			return gitConfigSync(cc, c, args[0], desired, pruneFlag, forceFlag)
+		},
+	}
+
+	// Flags and options
+	importCmd.Flags().BoolVar(&pruneFlag, "prune", false, "Remove keys missing from file")
+	importCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+
+	return importCmd
+}
+
+// NewCmdGitConfigCopy copies configuration between repositories
+func NewCmdGitConfigCopy() *cobra.Command {
+	var pruneFlag, forceFlag bool
+
+	copyCmd := &cobra.Command{
+		Use:   "copy SRC DST",
+		Short: "Copy Git build environment to another repo",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("Please specify source and destination repositories")
+			}
+
+			cc := cmd.Context()
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			srcConfig, err := c.GitConfig(cc, args[0])
+			if err != nil {
+				return err
+			}
+
+			desired := make(map[string]string, len(srcConfig))
+			for _, pair := range srcConfig {
+				desired[pair.Key] = pair.Value
+			}
+
+			return gitConfigSync(cc, c, args[1], desired, pruneFlag, forceFlag)
+		},
+	}
+
+	// Flags and options
+	copyCmd.Flags().BoolVar(&pruneFlag, "prune", false, "Remove keys missing from source")
+	copyCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+
+	return copyCmd
+}
+
+// Preview and apply changes to bring repo config to the desired state
+func gitConfigSync(cc context.Context, c *api.Client, repo string, desired map[string]string, prune, force bool) error {
+	term := ctx.Terminal(cc)
+
+	current, err := c.GitConfig(cc, repo)
+	if err != nil {
+		return err
+	}
+
+	changes := gitConfigChanges(current, desired, prune)
+	if len(changes) == 0 {
+		term.Printf("No changes to %s repository config\n", repo)
+		return nil
+	}
+
+	termPrintGitConfigChanges(term, "", current, changes)
+
+	if !force {
+		confirm := fmt.Sprintf("Apply %d changes to %s? [y/N]", len(changes), repo)
+		if ok, err := terminal.PromptConfirm(term, confirm); !ok {
+			return err
+		}
+	}
+
+	if err := c.GitConfigSet(cc, repo, changes); err != nil {
+		return err
+	}
+
+	term.Printf("Updated %s repository config\n", repo)
+	return nil
+}
+
+// gitConfigChanges computes the GitConfigSet PATCH to reach desired config.
+// Keys missing from desired config are only removed when pruning.
+func gitConfigChanges(current []api.GitConfigPair, desired map[string]string, prune bool) map[string]*string {
+	existing := make(map[string]string, len(current))
+	for _, pair := range current {
+		existing[pair.Key] = pair.Value
+	}
+
+	changes := map[string]*string{}
+	for k, v := range desired {
+		if old, ok := existing[k]; !ok || old != v {
+			v := v
+			changes[k] = &v
+		}
+	}
+
+	if prune {
+		for k := range existing {
+			if _, ok := desired[k]; !ok {
+				changes[k] = nil
+			}
+		}
+	}
+
+	return changes
+}
+
+// Diff-style preview of changed keys: "+" added, "~" changed, "-" removed.
+// Values are not shown, since they are often secrets.
+func termPrintGitConfigChanges(term terminal.Terminal, indent string, current []api.GitConfigPair, changes map[string]*string) {
+	existing := make(map[string]bool, len(current))
+	for _, pair := range current {
+		existing[pair.Key] = true
+	}
+
+	keys := make([]string, 0, len(changes))
+	for k := range changes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if changes[k] == nil {
+			term.Printf("%s- %s\n", indent, k)
+		} else if existing[k] {
+			term.Printf("%s~ %s\n", indent, k)
+		} else {
+			term.Printf("%s+ %s\n", indent, k)
+		}
+	}
+}
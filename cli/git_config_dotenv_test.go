@@ -0,0 +1,226 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ==== GIT CONFIG EXPORT ====
+
+func TestGitConfigExportCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	path := "/git/repos/me/repo-name/config-vars"
+	server := testutil.APIServer(t, "GET", path, `{ "config_vars": {
+		"KEY2": "two words",
+		"KEY1": "VALUE1"
+	}}`, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"git", "config", "export", "repo-name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "KEY1=VALUE1\nKEY2=\"two words\"\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+}
+
+func TestGitConfigExportCommandUnauthorized(t *testing.T) {
+	path := "/git/repos/me/repo-name/config-vars"
+	server := testutil.APIServer(t, "GET", path, "{}", 200)
+	testCommandLoginPreCheck(t, []string{"git", "config", "export", "repo-name"}, server)
+	server.Close()
+}
+
+func TestGitConfigExportCommandForbidden(t *testing.T) {
+	path := "/git/repos/me/repo-name/config-vars"
+	server := testutil.APIServer(t, "GET", path, "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "config", "export", "repo-name"}, server)
+	server.Close()
+}
+
+// ==== GIT CONFIG IMPORT ====
+
+func TestGitConfigImportCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	envPath := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(envPath, []byte(`# Comment
+export KEY2="new\nvalue" # Changed
+KEY3=added
+`), 0600)
+
+	// Each case is args, prompt response, and expected PATCH
+	cases := []struct {
+		args   []string
+		prompt string
+		patch  string
+	}{
+		{[]string{"--force"}, "", `{"KEY2":"new\nvalue","KEY3":"added"}`},
+		{[]string{"--prune"}, "y", `{"KEY1":null,"KEY2":"new\nvalue","KEY3":"added"}`},
+		{[]string{}, "ABORT", ""},
+	}
+
+	prompts := map[string]string{}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		patches := map[string]string{}
+		server := testGitConfigSyncServer(t, patches)
+
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		prompts["Apply 2 changes to repo-name? [y/N]"] = c.prompt
+		prompts["Apply 3 changes to repo-name? [y/N]"] = c.prompt
+		term.SetPromptResponses(prompts)
+
+		args := append([]string{"git", "config", "import", "repo-name", envPath}, c.args...)
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if p := patches["repo-name"]; p != c.patch {
+			t.Errorf("%v: Expected PATCH %s, got %s", c.args, c.patch, p)
+		}
+
+		outStr := string(term.OutBytes())
+		if exp := "~ KEY2\n+ KEY3\n"; !strings.Contains(outStr, exp) {
+			t.Errorf("%v: Expected preview %q, got %q", c.args, exp, outStr)
+		}
+
+		server.Close()
+	}
+}
+
+func TestGitConfigImportCommandStdin(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	patches := map[string]string{}
+	server := testGitConfigSyncServer(t, patches)
+	defer server.Close()
+
+	// Without --force, fails before reading stdin
+	term := terminal.NewForTest()
+	term.InWrite([]byte("KEY3=added\n"))
+	cc := cli.TestContext(term, auth)
+	err := runCommand(cc, []string{"git", "config", "import", "repo-name", "-"})
+	if exp := "Reading from stdin requires --force"; err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	}
+
+	term = terminal.NewForTest()
+	term.InWrite([]byte("KEY3=added\n"))
+	cc = cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err = runCommandNoErr(cc, []string{"git", "config", "import", "repo-name", "-", "--force"})
+	if err != nil {
+		t.Fatal(err)
+	} else if exp := `{"KEY3":"added"}`; patches["repo-name"] != exp {
+		t.Errorf("Expected PATCH %s, got %s", exp, patches["repo-name"])
+	}
+}
+
+func TestGitConfigImportCommandUnauthorized(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(envPath, []byte("KEY1=VALUE1\n"), 0600)
+
+	server := testGitConfigSyncServer(t, map[string]string{})
+	testCommandLoginPreCheck(t, []string{"git", "config", "import", "repo-name", envPath}, server)
+	server.Close()
+}
+
+func TestGitConfigImportCommandForbidden(t *testing.T) {
+	envPath := filepath.Join(t.TempDir(), ".env")
+	os.WriteFile(envPath, []byte("KEY1=VALUE1\n"), 0600)
+
+	path := "/git/repos/me/repo-name/config-vars"
+	server := testutil.APIServer(t, "GET", path, "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "config", "import", "repo-name", envPath}, server)
+	server.Close()
+}
+
+// ==== GIT CONFIG COPY ====
+
+func TestGitConfigCopyCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	patches := map[string]string{}
+	server := testGitConfigSyncServer(t, patches)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"git", "config", "copy", "repo-name", "other-repo", "--force"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if p, exp := patches["other-repo"], `{"KEY1":"VALUE1","KEY2":"VALUE2"}`; p != exp {
+		t.Errorf("Expected PATCH %s, got %s", exp, p)
+	}
+
+	exp := "+ KEY1\n+ KEY2\nUpdated other-repo repository config\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+}
+
+func TestGitConfigCopyCommandUnauthorized(t *testing.T) {
+	server := testGitConfigSyncServer(t, map[string]string{})
+	testCommandLoginPreCheck(t, []string{"git", "config", "copy", "repo-name", "other-repo", "-f"}, server)
+	server.Close()
+}
+
+func TestGitConfigCopyCommandForbidden(t *testing.T) {
+	path := "/git/repos/me/repo-name/config-vars"
+	server := testutil.APIServer(t, "GET", path, "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "config", "copy", "repo-name", "other-repo"}, server)
+	server.Close()
+}
+
+// Serves config for "repo-name", empty config for others, and records PATCH requests
+func testGitConfigSyncServer(t *testing.T, patches map[string]string) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/{repo}/config-vars", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			repo := r.PathValue("repo")
+
+			if r.Method == "PATCH" {
+				body := struct {
+					ConfigVars json.RawMessage `json:"config_vars"`
+				}{}
+				json.NewDecoder(r.Body).Decode(&body)
+				patches[repo] = string(body.ConfigVars)
+				w.Write([]byte("{}"))
+			} else if repo == "repo-name" {
+				w.Write([]byte(gitConfigResponse))
+			} else {
+				w.Write([]byte(`{ "config_vars": {} }`))
+			}
+		})
+	})
+}
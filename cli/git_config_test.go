@@ -5,6 +5,9 @@ import (
 	"github.com/gemfury/cli/internal/ctx"
 	"github.com/gemfury/cli/internal/testutil"
 	"github.com/gemfury/cli/pkg/terminal"
+
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -32,12 +35,24 @@ func TestGitConfigCommandSuccess(t *testing.T) {
 	flags := ctx.GlobalFlags(cc)
 	flags.Endpoint = server.URL
 
+	// Values are masked by default
 	err := runCommandNoErr(cc, []string{"git", "config", "repo-name"})
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	exp := "KEY1: VALUE1 KEY2: VALUE2"
+	exp := "KEY1: ******** KEY2: ********"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+
+	// Values are shown with "--reveal"
+	err = runCommandNoErr(cc, []string{"git", "config", "repo-name", "--reveal"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp = "KEY1: VALUE1 KEY2: VALUE2"
 	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
 		t.Errorf("Expected output to include %q, got %q", exp, outStr)
 	}
@@ -125,6 +140,61 @@ func TestGitConfigSetCommandSuccess(t *testing.T) {
 	}
 }
 
+func TestGitConfigSetSecretCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	secretPath := filepath.Join(t.TempDir(), "key.pem")
+	os.WriteFile(secretPath, []byte("-----BEGIN KEY-----\nabc\n"), 0600)
+
+	// Each case is args, stdin, and expected PATCH
+	cases := []struct {
+		args  []string
+		stdin string
+		patch string
+	}{
+		{[]string{"--from-file", secretPath}, "", `{"KEY2":"-----BEGIN KEY-----\nabc"}`},
+		{[]string{"--stdin"}, "s3cr3t\n", `{"KEY2":"s3cr3t"}`},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		term.InWrite([]byte(c.stdin))
+
+		patches := map[string]string{}
+		server := testGitConfigSyncServer(t, patches)
+
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		args := append([]string{"git", "config", "set", "repo-name", "KEY2"}, c.args...)
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if p := patches["repo-name"]; p != c.patch {
+			t.Errorf("%v: Expected PATCH %s, got %s", c.args, c.patch, p)
+		}
+
+		server.Close()
+	}
+
+	// Value must not be passed as an argument
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, auth)
+	err := runCommand(cc, []string{"git", "config", "set", "repo-name", "KEY2=VAL", "--stdin"})
+	if err == nil || !strings.Contains(err.Error(), "without value") {
+		t.Errorf("Expected argument error, got %v", err)
+	}
+
+	// Value comes from only one source
+	args := []string{"git", "config", "set", "repo-name", "KEY2", "--stdin", "--from-file", secretPath}
+	err = runCommand(cc, args)
+	if err == nil || !strings.Contains(err.Error(), "--from-file can't be used with --stdin") {
+		t.Errorf("Expected conflicting flags error, got %v", err)
+	}
+}
+
 func TestGitConfigSetCommandUnauthorized(t *testing.T) {
 	path := "/git/repos/me/repo-name/config-vars"
 	server := testutil.APIServer(t, "PATCH", path, "{}", 200)
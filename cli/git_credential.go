@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Host of Gemfury Git repositories
+const furyGitHost = "git.fury.io"
+
+// Git credential helper, resolved from PATH so it survives CLI upgrades
+const gitCredentialHelper = "!fury git credential"
+
+// NewCmdGitCredential implements Git's credential helper protocol
+func NewCmdGitCredential() *cobra.Command {
+	credentialCmd := &cobra.Command{
+		Use:   "credential",
+		Short: "Git credential helper for Gemfury Git",
+		Long: `Git credential helper using your Gemfury CLI login. To enable:
+
+  git config --global credential.https://git.fury.io.helper '!fury git credential'`,
+
+		// Git runs helpers non-interactively, so never prompt to login
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	credentialCmd.AddCommand(&cobra.Command{
+		Use:   "get",
+		Short: "Return credentials for Gemfury Git",
+		RunE:  gitCredentialGet,
+	})
+
+	credentialCmd.AddCommand(&cobra.Command{
+		Use:   "store",
+		Short: "Ignored, credentials come from your CLI login",
+		RunE:  gitCredentialIgnore,
+	})
+
+	credentialCmd.AddCommand(&cobra.Command{
+		Use:   "erase",
+		Short: "Ignored, use \"fury logout\" to remove your login",
+		RunE:  gitCredentialIgnore,
+	})
+
+	return credentialCmd
+}
+
+func gitCredentialGet(cmd *cobra.Command, args []string) error {
+	cc := cmd.Context()
+	term := ctx.Terminal(cc)
+
+	attrs, err := readGitCredential(term.IOIn())
+	if err != nil || !isFuryGitCredential(attrs) {
+		return err
+	}
+
+	user, token, err := ctx.Auther(cc).Auth()
+	if err != nil {
+		return err
+	}
+
+	// Inline token takes precedence, as with API commands
+	if t := ctx.GlobalFlags(cc).AuthToken; t != "" {
+		token = t
+	}
+
+	// Git falls back to other helpers when nothing is returned
+	if token == "" {
+		return nil
+	} else if user == "" {
+		user = token
+	}
+
+	term.Printf("username=%s\npassword=%s\n\n", user, token)
+	return nil
+}
+
+// Git reports accepted and rejected credentials with "store" and "erase".
+// The CLI login is not a Git credential store, so it's never changed here.
+func gitCredentialIgnore(cmd *cobra.Command, args []string) error {
+	_, err := readGitCredential(ctx.Terminal(cmd.Context()).IOIn())
+	return err
+}
+
+// Reads "key=value" lines from Git until a blank line or EOF
+func readGitCredential(in io.Reader) (map[string]string, error) {
+	attrs := map[string]string{}
+	scanner := bufio.NewScanner(in)
+
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+
+		pair := strings.SplitN(line, "=", 2)
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("Invalid credential input: %q", line)
+		}
+		attrs[pair[0]] = pair[1]
+	}
+
+	return attrs, scanner.Err()
+}
+
+// Helper only answers for Gemfury Git over HTTPS
+func isFuryGitCredential(attrs map[string]string) bool {
+	return attrs["protocol"] == "https" && attrs["host"] == furyGitHost
+}
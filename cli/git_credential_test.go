@@ -0,0 +1,65 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"testing"
+)
+
+const gitCredentialInput = "protocol=https\nhost=git.fury.io\npath=me/repo-name.git\n"
+
+// ==== GIT CREDENTIAL ====
+
+func TestGitCredentialGetCommand(t *testing.T) {
+	// Each case is input, auther (user, token), and expected output
+	cases := []struct {
+		in, user, token, out string
+	}{
+		{gitCredentialInput + "\n", "user@example.com", "abc123", "username=user@example.com\npassword=abc123\n\n"},
+		{gitCredentialInput, "", "abc123", "username=abc123\npassword=abc123\n\n"},
+		{gitCredentialInput, "", "", ""}, // Not logged in, no login prompt
+		{"protocol=https\nhost=github.com\n", "user", "abc123", ""},
+		{"protocol=http\nhost=git.fury.io\n", "user", "abc123", ""},
+	}
+
+	for _, c := range cases {
+		auth := terminal.TestAuther(c.user, c.token, nil)
+		term := terminal.NewForTest()
+		term.InWrite([]byte(c.in))
+
+		cc := cli.TestContext(term, auth)
+		if err := runCommandNoErr(cc, []string{"git", "credential", "get"}); err != nil {
+			t.Fatalf("%q: %s", c.in, err)
+		}
+
+		if outStr := string(term.OutBytes()); outStr != c.out {
+			t.Errorf("%q: Expected output %q, got %q", c.in, c.out, outStr)
+		}
+	}
+}
+
+func TestGitCredentialStoreEraseCommands(t *testing.T) {
+	inputs := []string{
+		gitCredentialInput + "username=user\npassword=xyz789\n",
+		gitCredentialInput + "username=user\npassword=abc123\n",
+	}
+
+	// CLI login is neither replaced nor wiped by Git
+	for _, sub := range []string{"store", "erase"} {
+		for _, in := range inputs {
+			auth := terminal.TestAuther("user", "abc123", nil)
+			term := terminal.NewForTest()
+			term.InWrite([]byte(in))
+
+			cc := cli.TestContext(term, auth)
+			if err := runCommandNoErr(cc, []string{"git", "credential", sub}); err != nil {
+				t.Fatalf("%s: %s", sub, err)
+			}
+
+			if auth.User != "user" || auth.Pass != "abc123" {
+				t.Errorf("%s: Expected login to be kept, got %q/%q", sub, auth.User, auth.Pass)
+			}
+		}
+	}
+}
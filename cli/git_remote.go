@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// NewCmdGitRemote is the root for managing Gemfury remotes of a local repo
+func NewCmdGitRemote() *cobra.Command {
+	remoteCmd := &cobra.Command{
+		Use:   "remote",
+		Short: "Manage Gemfury remote of local Git repo",
+	}
+
+	remoteCmd.AddCommand(NewCmdGitRemoteAdd())
+
+	return remoteCmd
+}
+
+// NewCmdGitRemoteAdd adds a Gemfury remote to the local Git repository
+func NewCmdGitRemoteAdd() *cobra.Command {
+	var nameFlag string
+	var createFlag bool
+
+	addCmd := &cobra.Command{
+		Use:   "add [REPO]",
+		Short: "Add Gemfury remote to local Git repo",
+		Long: `Add Gemfury remote to the Git repository in the current directory,
+and use "fury git credential" to authenticate when pushing to it.
+
+REPO defaults to the name of the local repository directory.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 1 {
+				return fmt.Errorf("Please specify at most one repository")
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+
+			topLevel, err := runGit(cc, "rev-parse", "--show-toplevel")
+			if err != nil {
+				return fmt.Errorf("Not inside a Git repository")
+			}
+
+			repo := filepath.Base(topLevel)
+			if len(args) == 1 {
+				repo = args[0]
+			}
+
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			// Remote URL needs a real username instead of "me"
+			if c.Account == "" {
+				who, err := c.WhoAmI(cc)
+				if err != nil {
+					return err
+				}
+				c = c.WithAccount(who.Username)
+			}
+
+			// Existing remote is fine, if it already points to this repo
+			existing, _ := runGit(cc, "remote", "get-url", nameFlag)
+
+			remoteURL, err := gitRemoteURL(cc, c, repo, createFlag && existing == "")
+			if err != nil {
+				return err
+			}
+
+			if existing == "" {
+				if _, err := runGit(cc, "remote", "add", nameFlag, remoteURL); err != nil {
+					return err
+				}
+			} else if existing != remoteURL {
+				return fmt.Errorf("Remote %q already exists for %s", nameFlag, existing)
+			}
+
+			key := "credential.https://" + furyGitHost + ".helper"
+			if _, err := runGit(cc, "config", key, gitCredentialHelper); err != nil {
+				return err
+			}
+
+			term.Printf("Added remote %s for %s\n", nameFlag, remoteURL)
+			term.Printf("Build your app with \"git push %s main\"\n", nameFlag)
+			return nil
+		},
+	}
+
+	// Flags and options
+	addCmd.Flags().StringVar(&nameFlag, "name", "fury", "Name of Git remote")
+	addCmd.Flags().BoolVar(&createFlag, "create", false, "Create repo if it doesn't exist")
+
+	return addCmd
+}
+
+// Clone URL of an existing repo, creating it first if requested
+func gitRemoteURL(cc context.Context, c *api.Client, repo string, create bool) (string, error) {
+	info, err := c.GitInfo(cc, repo)
+	if errors.Is(err, api.ErrNotFound) && create {
+		info, err = c.GitCreate(cc, repo)
+	} else if errors.Is(err, api.ErrNotFound) {
+		return "", fmt.Errorf("Repository %q not found. Use --create to create it", repo)
+	}
+
+	if err != nil {
+		return "", err
+	} else if info.CloneURL != "" {
+		return info.CloneURL, nil
+	}
+
+	return c.GitRemoteURL(info.Name)
+}
+
+// Runs git command in current directory, returning trimmed output
+func runGit(cc context.Context, args ...string) (string, error) {
+	out, err := exec.CommandContext(cc, "git", args...).Output()
+	if exitErr := (*exec.ExitError)(nil); errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return strings.TrimSpace(string(out)), err
+}
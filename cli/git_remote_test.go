@@ -0,0 +1,151 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// ==== GIT REMOTE ADD ====
+
+func TestGitRemoteAddCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	created := false
+	server := testGitRemoteServer(t, &created)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Repo name defaults to directory name
+	testGitRemoteWorkdir(t, "new-repo")
+
+	// Missing repository requires "--create"
+	err := runCommand(cc, []string{"git", "remote", "add"})
+	if err == nil || !strings.Contains(err.Error(), "--create") {
+		t.Fatalf("Expected not found error, got %v", err)
+	}
+
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, auth)
+	ctx.GlobalFlags(cc).Endpoint = server.URL
+
+	err = runCommandNoErr(cc, []string{"git", "remote", "add", "--create"})
+	if err != nil {
+		t.Fatal(err)
+	} else if !created {
+		t.Errorf("Expected repository to be created")
+	}
+
+	exp := "https://git.fury.io/joetest/new-repo.git"
+	if url := testGitOutput(t, "remote", "get-url", "fury"); url != exp {
+		t.Errorf("Expected remote %q, got %q", exp, url)
+	}
+
+	helper := testGitOutput(t, "config", "credential.https://git.fury.io.helper")
+	if exp := "!fury git credential"; helper != exp {
+		t.Errorf("Expected credential helper %q, got %q", exp, helper)
+	}
+
+	// Running again is a no-op
+	err = runCommandNoErr(cc, []string{"git", "remote", "add"})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGitRemoteAddCommandExistingRepo(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitRemoteServer(t, new(bool))
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	testGitRemoteWorkdir(t, "workdir")
+
+	// Account flag skips lookup of current user
+	err := runCommandNoErr(cc, []string{"git", "remote", "add", "repo-name", "--name", "gf", "-a", "me"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "https://git.fury.io/me/repo-name.git"
+	if url := testGitOutput(t, "remote", "get-url", "gf"); url != exp {
+		t.Errorf("Expected remote %q, got %q", exp, url)
+	}
+}
+
+func TestGitRemoteAddCommandUnauthorized(t *testing.T) {
+	testGitRemoteWorkdir(t, "repo-name")
+	server := testGitRemoteServer(t, new(bool))
+	testCommandLoginPreCheck(t, []string{"git", "remote", "add"}, server)
+	server.Close()
+}
+
+func TestGitRemoteAddCommandForbidden(t *testing.T) {
+	testGitRemoteWorkdir(t, "repo-name")
+	server := testutil.APIServer(t, "GET", "/users/me", "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "remote", "add"}, server)
+	server.Close()
+}
+
+// Changes into an empty Git repository in a directory with the given name
+func testGitRemoteWorkdir(t *testing.T, name string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := filepath.Join(t.TempDir(), name)
+	if out, err := exec.Command("git", "init", "-q", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %s", out)
+	}
+
+	t.Chdir(dir)
+}
+
+func testGitOutput(t *testing.T, args ...string) string {
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		t.Fatalf("git %s: %s", args[0], err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// Serves current user, "repo-name" info, and creation of other repos
+func testGitRemoteServer(t *testing.T, created *bool) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(`{ "username": "joetest" }`))
+		})
+		mux.HandleFunc("/git/repos/{acct}/{repo}", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			if r.PathValue("repo") == "repo-name" || *created {
+				w.Write([]byte(`{ "repo": { "name": "` + r.PathValue("repo") + `" } }`))
+			} else {
+				w.WriteHeader(404)
+				w.Write([]byte("{}"))
+			}
+		})
+		mux.HandleFunc("POST /git/repos/joetest", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			*created = true
+			w.Write([]byte(`{ "repo": { "name": "` + r.URL.Query().Get("repo[name]") + `" } }`))
+		})
+	})
+}
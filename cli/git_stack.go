@@ -1,10 +1,16 @@
 package cli
 
 import (
+	"github.com/gemfury/cli/api"
 	"github.com/gemfury/cli/internal/ctx"
+	"github.com/manifoldco/promptui"
 	"github.com/spf13/cobra"
 
+	"context"
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // NewCmdGitStack is the root for Git Config
@@ -60,17 +66,61 @@ func gitStackForRepo(cmd *cobra.Command, repoName string) error {
 
 // NewCmdGitStackSet updates one or more configuration keys
 func NewCmdGitStackSet() *cobra.Command {
+	var latestFlag bool
+	var batch gitBatchFlags
+
 	gitStackSetCmd := &cobra.Command{
-		Use:   "set REPO STACK",
+		Use:   "set REPO [STACK]",
 		Short: "Set Git stack for repo",
+		Long: `Set Git stack for repo. Without STACK, select it from a list.
+
+With --all or --match, omit REPO to update many repos at once.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) < 2 {
+			n := batch.repoArgs()
+			if len(args) < n || len(args) > n+1 || (latestFlag && len(args) > n) {
 				return fmt.Errorf("Please specify a repository and a stack")
+			} else if batch.enabled() && !latestFlag && len(args) == 0 {
+				return fmt.Errorf("Please specify a stack, or use --latest")
+			}
+
+			cc := cmd.Context()
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			stacks, err := c.GitStacks(cc)
+			if err != nil {
+				return err
 			}
-			return gitStackUpdate(cmd, args[0], args[1])
+
+			var stack string
+			if latestFlag {
+				if stack = latestGitStack(stacks); stack == "" {
+					return fmt.Errorf("No build stacks available")
+				}
+			} else if len(args) > n {
+				stack, err = validGitStack(stacks, args[n])
+			} else {
+				stack, err = selectGitStack(cmd, c, args[0], stacks)
+			}
+
+			if err != nil || stack == "" {
+				return err
+			} else if batch.enabled() {
+				return gitBatchRun(cmd, &batch, func(cc context.Context, c *api.Client, repo string) error {
+					return c.GitStackSet(cc, repo, stack)
+				})
+			}
+
+			return gitStackUpdate(cmd, args[0], stack)
 		},
 	}
 
+	// Flags and options
+	gitStackSetCmd.Flags().BoolVar(&latestFlag, "latest", false, "Use newest available stack")
+	batch.register(gitStackSetCmd)
+
 	return gitStackSetCmd
 }
 
@@ -87,6 +137,78 @@ func gitStackUpdate(cmd *cobra.Command, repo string, newStack string) error {
 		return err
 	}
 
-	term.Printf("Updated %s repository build stack\n", repo)
+	term.Printf("Updated %s repository build stack to %s\n", repo, newStack)
 	return nil
 }
+
+// Ensure stack exists, suggesting close matches for typos
+func validGitStack(stacks []api.GitStack, name string) (string, error) {
+	names := gitStackNames(stacks)
+	for _, n := range names {
+		if n == name {
+			return name, nil
+		}
+	}
+
+	if matches := closestMatches(name, names); len(matches) > 0 {
+		return "", fmt.Errorf("Unknown build stack %q. Did you mean %q?", name, matches[0])
+	}
+
+	return "", fmt.Errorf("Unknown build stack %q. Available: %s", name, strings.Join(names, ", "))
+}
+
+// Interactive selection, starting at the current stack of the repo
+func selectGitStack(cmd *cobra.Command, c *api.Client, repoName string, stacks []api.GitStack) (string, error) {
+	cc := cmd.Context()
+	term := ctx.Terminal(cc)
+
+	if !term.IsInteractive() {
+		return "", fmt.Errorf("Please specify a stack, or use --latest")
+	}
+
+	repo, err := c.GitInfo(cc, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	names := gitStackNames(stacks)
+	cursor := 0
+	for i, n := range names {
+		if n == repo.Stack.Name {
+			cursor = i
+		}
+	}
+
+	prompt := promptui.Select{Label: "Select build stack", Items: names, CursorPos: cursor}
+	_, stack, err := term.RunSelect(&prompt)
+	if errors.Is(err, promptui.ErrAbort) || errors.Is(err, promptui.ErrInterrupt) {
+		return "", nil
+	}
+
+	return stack, err
+}
+
+// Newest stack has the highest version suffix, such as "fury-22"
+func latestGitStack(stacks []api.GitStack) string {
+	latest, latestNum := "", -1
+	for _, s := range stacks {
+		num := -1
+		if i := strings.LastIndexAny(s.Name, "-_"); i >= 0 {
+			if n, err := strconv.Atoi(s.Name[i+1:]); err == nil {
+				num = n
+			}
+		}
+		if num >= latestNum {
+			latest, latestNum = s.Name, num
+		}
+	}
+	return latest
+}
+
+func gitStackNames(stacks []api.GitStack) []string {
+	names := make([]string, len(stacks))
+	for i, s := range stacks {
+		names[i] = s.Name
+	}
+	return names
+}
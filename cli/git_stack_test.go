@@ -80,38 +80,113 @@ func testGitStackServer(t *testing.T) *httptest.Server {
 
 func TestGitStackSetCommandSuccess(t *testing.T) {
 	auth := terminal.TestAuther("user", "abc123", nil)
-	term := terminal.NewForTest()
 
-	// Fire up test server
-	path := "/git/repos/me/repo-name"
-	server := testutil.APIServer(t, "PATCH", path, "{}", 200)
+	server := testGitStackServer(t)
 	defer server.Close()
 
-	cc := cli.TestContext(term, auth)
-	flags := ctx.GlobalFlags(cc)
-	flags.Endpoint = server.URL
+	// Each case is args, selected stack, and expected output
+	cases := []struct {
+		args   []string
+		choice string
+		out    string
+	}{
+		{[]string{"fury-22"}, "", "Updated repo-name repository build stack to fury-22"},
+		{[]string{"--latest"}, "", "Updated repo-name repository build stack to fury-22"},
+		{[]string{}, "fury-14", "Updated repo-name repository build stack to fury-14"},
+		{[]string{}, "ABORT", ""},
+	}
 
-	err := runCommandNoErr(cc, []string{"git", "stack", "set", "repo-name", "fury-22"})
-	if err != nil {
-		t.Fatal(err)
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		if c.choice != "" {
+			term.SetPromptResponses(map[string]string{"Select build stack": c.choice})
+		}
+
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		args := append([]string{"git", "stack", "set", "repo-name"}, c.args...)
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := compactString(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
 	}
+}
 
-	exp := "Updated repo-name repository build stack"
-	if outStr := compactString(term.OutBytes()); outStr != exp {
-		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+func TestGitStackSetCommandInvalid(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	server := testGitStackServer(t)
+	defer server.Close()
+
+	// Each case is stack argument and expected error
+	cases := map[string]string{
+		"fury-2":  `Unknown build stack "fury-2". Did you mean "fury-22"?`,
+		"fury-15": `Unknown build stack "fury-15". Did you mean "fury-14"?`,
+		"heroku":  `Unknown build stack "heroku". Available: fury-14, fury-22`,
+	}
+
+	for stack, exp := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommand(cc, []string{"git", "stack", "set", "repo-name", stack})
+		if err == nil || err.Error() != exp {
+			t.Errorf("%s: Expected error %q, got %v", stack, exp, err)
+		}
+	}
+
+	// No stack without a terminal to select from
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, auth)
+	ctx.GlobalFlags(cc).Endpoint = server.URL
+	err := runCommand(cc, []string{"git", "stack", "set", "repo-name"})
+	if err == nil || !strings.Contains(err.Error(), "--latest") {
+		t.Errorf("Expected missing stack error, got %v", err)
+	}
+
+	// No latest stack when none are available
+	emptyServer := testutil.APIServer(t, "GET", "/git/stacks", "[]", 200)
+	defer emptyServer.Close()
+
+	cc = cli.TestContext(terminal.NewForTest(), auth)
+	ctx.GlobalFlags(cc).Endpoint = emptyServer.URL
+	err = runCommand(cc, []string{"git", "stack", "set", "repo-name", "--latest"})
+	if exp := "No build stacks available"; err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
 	}
 }
 
 func TestGitStackSetCommandUnauthorized(t *testing.T) {
-	path := "/git/repos/me/repo-name"
-	server := testutil.APIServer(t, "PATCH", path, "{}", 200)
+	server := testGitStackServer(t)
 	testCommandLoginPreCheck(t, []string{"git", "stack", "set", "repo-name", "fury-22"}, server)
 	server.Close()
 }
 
 func TestGitStackSetCommandForbidden(t *testing.T) {
-	path := "/git/repos/me/repo-name"
-	server := testutil.APIServer(t, "PATCH", path, "", 403)
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/repo-name", func(w http.ResponseWriter, r *http.Request) {
+			if m := r.Method; m != "PATCH" {
+				t.Errorf("Incorrect method: %q", m)
+			}
+			w.WriteHeader(403)
+		})
+		mux.HandleFunc("/git/stacks", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(gitStacksResponse))
+		})
+	})
+	testCommandForbiddenResponse(t, []string{"git", "stack", "set", "repo-name", "fury-22"}, server)
+	server.Close()
+}
+
+func TestGitStackSetCommandStacksForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/git/stacks", "", 403)
 	testCommandForbiddenResponse(t, []string{"git", "stack", "set", "repo-name", "fury-22"}, server)
 	server.Close()
 }
@@ -6,13 +6,31 @@ import (
 	"github.com/gemfury/cli/internal/testutil"
 	"github.com/gemfury/cli/pkg/terminal"
 
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 )
 
 const (
 	gitRebuildResponse = "Build done!\n"
+	gitBuildsResponse  = `{ "builds": [{
+		"id": "bld_a1b2c3",
+		"revision": "9f8e7d6",
+		"status": %q,
+		"created_at": "2011-05-27T00:39:07+00:00",
+		"finished_at": "2011-05-27T00:40:37+00:00",
+		"created_by": { "name": "user1" }
+	}]}`
+	gitBuildInfoResponse = `{ "build": {
+		"id": "bld_d4e5f6",
+		"revision": "9f8e7d6",
+		"status": %q,
+		"created_at": "2011-05-27T00:39:07+00:00",
+		"finished_at": "2011-05-27T00:40:37+00:00"
+	}}`
 )
 
 // ==== GIT REBUILD ====
@@ -33,8 +51,12 @@ func TestGitRebuildCommandSuccess(t *testing.T) {
 				t.Errorf("Incorrect method: %q", m)
 			}
 			revDst = r.URL.Query().Get("build[revision]")
+			w.Header().Set("X-Build-Id", "bld_d4e5f6")
 			w.Write([]byte(gitRebuildResponse))
 		})
+		mux.HandleFunc(path+"/bld_d4e5f6", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(gitBuildInfoResponse, "success")))
+		})
 	})
 	defer server.Close()
 
@@ -85,9 +107,78 @@ func TestGitRebuildCommandSuccess(t *testing.T) {
 	}
 }
 
-func TestGitRebuildCommandUnauthorized(t *testing.T) {
+func TestGitRebuildCommandFailure(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitRebuildServer(t, "failed")
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommand(cc, []string{"git", "rebuild", "repo-name"})
+	if err == nil || err.Error() != "Build bld_d4e5f6 failed" {
+		t.Errorf("Expected build failure, got %v", err)
+	} else if code := cli.ExitCode(err); code != 2 {
+		t.Errorf("Expected exit code 2, got %d", code)
+	}
+}
+
+func TestGitRebuildCommandConcurrentBuild(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	// Newest build in the listing is from a concurrent push
+	path := "/git/repos/me/repo-name/builds"
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "GET" {
+				w.Write([]byte(fmt.Sprintf(gitBuildsResponse, "success")))
+				return
+			}
+			w.Header().Set("X-Build-Id", "bld_d4e5f6")
+			w.Write([]byte(gitRebuildResponse))
+		})
+		mux.HandleFunc(path+"/bld_d4e5f6", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(gitBuildInfoResponse, "failed")))
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(terminal.NewForTest(), auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommand(cc, []string{"git", "rebuild", "repo-name"})
+	if err == nil || err.Error() != "Build bld_d4e5f6 failed" {
+		t.Errorf("Expected failure of triggered build, got %v", err)
+	}
+}
+
+func TestGitRebuildCommandNoBuildID(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
 	path := "/git/repos/me/repo-name/builds"
 	server := testutil.APIServer(t, "POST", path, gitRebuildResponse, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Streamed build succeeds, even if its result can't be looked up
+	err := runCommand(cc, []string{"git", "rebuild", "repo-name"})
+	if err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	} else if errStr := string(term.ErrBytes()); !strings.Contains(errStr, "Unable to determine result of repo-name build") {
+		t.Errorf("Expected warning, got %q", errStr)
+	}
+}
+
+func TestGitRebuildCommandUnauthorized(t *testing.T) {
+	server := testGitRebuildServer(t, "success")
 	testCommandLoginPreCheck(t, []string{"git", "rebuild", "repo-name"}, server)
 	server.Close()
 }
@@ -99,6 +190,104 @@ func TestGitRebuildCommandForbidden(t *testing.T) {
 	server.Close()
 }
 
+func testGitRebuildServer(t *testing.T, status string) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/repo-name/builds", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Header().Set("X-Build-Id", "bld_d4e5f6")
+			w.Write([]byte(gitRebuildResponse))
+		})
+		mux.HandleFunc("/git/repos/me/repo-name/builds/bld_d4e5f6", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(gitBuildInfoResponse, status)))
+		})
+	})
+}
+
+// ==== GIT INFO ====
+
+const gitInfoDetailResponse = `{ "repo": {
+	"id": "repo_a1b2c3",
+	"name": "repo-name",
+	"build_stack": { "name": "fury-22" },
+	"clone_url": "https://git.fury.io/user/repo-name.git",
+	"size": 1572864,
+	"pushed_at": "2011-05-27T00:39:07+00:00",
+	"last_build": {
+		"id": "bld_a1b2c3",
+		"status": "success",
+		"created_at": "2011-05-27T00:40:00+00:00"
+	}
+}}`
+
+func TestGitInfoCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testGitInfoServer(t)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"git", "info", "repo-name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "clone_url: https://git.fury.io/user/repo-name.git build_stack: fury-22 " +
+		"size: 1.5 MB last_push: 2011-05-26 17:39 " +
+		"last_build: success (bld_a1b2c3, 2011-05-26 17:40) config_vars: 2"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+
+	// JSON output for scripts
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, auth)
+	flags = ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err = runCommandNoErr(cc, []string{"git", "info", "repo-name", "--json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(term.OutBytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %s", err)
+	} else if n := out["config_vars_count"]; n != float64(2) {
+		t.Errorf("Expected 2 config vars, got %v", n)
+	} else if u := out["clone_url"]; u != "https://git.fury.io/user/repo-name.git" {
+		t.Errorf("Expected clone URL, got %v", u)
+	}
+}
+
+func TestGitInfoCommandUnauthorized(t *testing.T) {
+	server := testGitInfoServer(t)
+	testCommandLoginPreCheck(t, []string{"git", "info", "repo-name"}, server)
+	server.Close()
+}
+
+func TestGitInfoCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/git/repos/me/repo-name", "", 403)
+	testCommandForbiddenResponse(t, []string{"git", "info", "repo-name"}, server)
+	server.Close()
+}
+
+func testGitInfoServer(t *testing.T) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/repo-name", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(gitInfoDetailResponse))
+		})
+		mux.HandleFunc("/git/repos/me/repo-name/config-vars", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			w.Write([]byte(gitConfigResponse))
+		})
+	})
+}
+
 // ==== GIT RENAME ====
 
 func TestGitRenameCommandSuccess(t *testing.T) {
@@ -114,7 +303,7 @@ func TestGitRenameCommandSuccess(t *testing.T) {
 	flags := ctx.GlobalFlags(cc)
 	flags.Endpoint = server.URL
 
-	err := runCommandNoErr(cc, []string{"git", "rename", "repo-name", "new-name"})
+	err := runCommandNoErr(cc, []string{"git", "rename", "repo-name", "new-name", "--force"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -128,7 +317,7 @@ func TestGitRenameCommandSuccess(t *testing.T) {
 func TestGitRenameCommandUnauthorized(t *testing.T) {
 	path := "/git/repos/me/repo-name"
 	server := testutil.APIServer(t, "PATCH", path, "{}", 200)
-	args := []string{"git", "rename", "repo-name", "new-name"}
+	args := []string{"git", "rename", "repo-name", "new-name", "-f"}
 	testCommandLoginPreCheck(t, args, server)
 	server.Close()
 }
@@ -136,7 +325,7 @@ func TestGitRenameCommandUnauthorized(t *testing.T) {
 func TestGitRenameCommandForbidden(t *testing.T) {
 	path := "/git/repos/me/repo-name"
 	server := testutil.APIServer(t, "PATCH", path, "", 403)
-	args := []string{"git", "rename", "repo-name", "new-name"}
+	args := []string{"git", "rename", "repo-name", "new-name", "-f"}
 	testCommandForbiddenResponse(t, args, server)
 	server.Close()
 }
@@ -163,7 +352,7 @@ func TestGitDestroyCommandSuccess(t *testing.T) {
 	flags := ctx.GlobalFlags(cc)
 	flags.Endpoint = serverDestroy.URL
 
-	err := runCommandNoErr(cc, []string{"git", "destroy", "repo-name"})
+	err := runCommandNoErr(cc, []string{"git", "destroy", "repo-name", "--force"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -189,7 +378,7 @@ func TestGitDestroyCommandSuccess(t *testing.T) {
 	flags = ctx.GlobalFlags(cc)
 	flags.Endpoint = serverReset.URL
 
-	err = runCommandNoErr(cc, []string{"git", "destroy", "--reset-only", "repo-name"})
+	err = runCommandNoErr(cc, []string{"git", "destroy", "--reset-only", "repo-name", "--force"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -204,7 +393,7 @@ func TestGitDestroyCommandSuccess(t *testing.T) {
 	flags = ctx.GlobalFlags(cc)
 	flags.Endpoint = serverReset.URL
 
-	err = runCommandNoErr(cc, []string{"git", "reset", "repo-name"})
+	err = runCommandNoErr(cc, []string{"git", "reset", "repo-name", "--force"})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -218,17 +407,86 @@ func TestGitDestroyCommandSuccess(t *testing.T) {
 func TestGitDestroyCommandUnauthorized(t *testing.T) {
 	path := "/git/repos/me/repo-name"
 	server := testutil.APIServer(t, "DELETE", path, "{}", 200)
-	testCommandLoginPreCheck(t, []string{"git", "destroy", "repo-name"}, server)
+	testCommandLoginPreCheck(t, []string{"git", "destroy", "repo-name", "-f"}, server)
 	server.Close()
 }
 
 func TestGitDestroyCommandForbidden(t *testing.T) {
 	path := "/git/repos/me/repo-name"
 	server := testutil.APIServer(t, "DELETE", path, "", 403)
-	testCommandForbiddenResponse(t, []string{"git", "destroy", "repo-name"}, server)
+	testCommandForbiddenResponse(t, []string{"git", "destroy", "repo-name", "-f"}, server)
 	server.Close()
 }
 
+func TestGitDestroyCommandConfirm(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	methods := []string{}
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/git/repos/me/repo-name", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			methods = append(methods, r.Method)
+			w.Write([]byte(gitInfoDetailResponse))
+		})
+	})
+	defer server.Close()
+
+	// Each case is args, typed confirmation, expected API calls, and output
+	cases := []struct {
+		args    []string
+		typed   string
+		methods string
+		out     string
+	}{
+		{[]string{"destroy", "repo-name"}, "repo-name", "GET DELETE", "Removed repo-name repository\n"},
+		{[]string{"reset", "repo-name"}, "repo-name", "GET DELETE", "Reset repo-name repository\n"},
+		{[]string{"destroy", "repo-name"}, "other", "GET", "Repository name did not match, nothing changed\n"},
+		{[]string{"destroy", "repo-name", "--dry-run"}, "", "GET", "Dry run, no changes made\n"},
+		{[]string{"rename", "repo-name", "new-name"}, "repo-name", "GET PATCH", "Renamed repo-name repository to new-name\n"},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		if c.typed != "" {
+			term.SetPromptResponses(map[string]string{`Type "repo-name" to confirm`: c.typed})
+		}
+
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		methods = methods[:0]
+		err := runCommandNoErr(cc, append([]string{"git"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if m := strings.Join(methods, " "); m != c.methods {
+			t.Errorf("%v: Expected requests %q, got %q", c.args, c.methods, m)
+		}
+
+		outStr := string(term.OutBytes())
+		if exp := "build_stack: fury-22"; !strings.Contains(compactString(term.OutBytes()), exp) {
+			t.Errorf("%v: Expected output to include %q, got %q", c.args, exp, outStr)
+		} else if !strings.HasSuffix(outStr, c.out) {
+			t.Errorf("%v: Expected output to include %q, got %q", c.args, c.out, outStr)
+		}
+	}
+
+	// Refuse to prompt without a terminal
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, auth)
+	ctx.GlobalFlags(cc).Endpoint = server.URL
+
+	methods = methods[:0]
+	err := runCommand(cc, []string{"git", "destroy", "repo-name"})
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Errorf("Expected refusal without --force, got %v", err)
+	} else if m := strings.Join(methods, " "); m != "GET" {
+		t.Errorf("Expected no changes, got requests %q", m)
+	}
+}
+
 // ==== GIT LIST ====
 
 var gitReposResponses = []string{`{ "repos": [{
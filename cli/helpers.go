@@ -1,8 +1,14 @@
 package cli
 
 import (
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -10,6 +16,13 @@ var (
 	roundDurationRE = regexp.MustCompile(`^\d+\w`)
 )
 
+// Exit codes for errors that scripts may want to tell apart
+const (
+	exitCodeError       = 1
+	exitCodeBuildFailed = 2
+	exitCodeDrift       = 3
+)
+
 func timeStringWithAgo(t time.Time) string {
 	out := t.Local().Format("2006-01-02 15:04")
 
@@ -22,3 +35,90 @@ func timeStringWithAgo(t time.Time) string {
 
 	return out
 }
+
+// Byte size in human-readable units, such as "1.5 MB"
+func humanSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// Candidates within a small edit distance of s, closest first, for
+// "Did you mean" suggestions on typos
+func closestMatches(s string, candidates []string) []string {
+	maxDist := len(s)/3 + 1
+	dists := map[string]int{}
+	matches := []string{}
+
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(s), strings.ToLower(c))
+		if d <= maxDist || strings.HasPrefix(c, s) {
+			dists[c] = d
+			matches = append(matches, c)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return dists[matches[i]] < dists[matches[j]]
+	})
+
+	return matches
+}
+
+// Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
+
+// Indented JSON output for scripting, instead of tables
+func termPrintJSON(term terminal.Terminal, v interface{}) error {
+	enc := json.NewEncoder(term.IOOut())
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// exitError is a command error with a specific process exit code
+type exitError struct {
+	error
+	code int
+}
+
+func (e exitError) Unwrap() error {
+	return e.error
+}
+
+// ExitCode returns the process exit code for an error returned by a command
+func ExitCode(err error) int {
+	var ee exitError
+	if errors.As(err, &ee) {
+		return ee.code
+	}
+	return exitCodeError
+}
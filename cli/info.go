@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/spf13/cobra"
+
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// NewCmdInfo generates the Cobra command for "info"
+func NewCmdInfo() *cobra.Command {
+	var jsonFlag bool
+
+	infoCmd := &cobra.Command{
+		Use:   "info PACKAGE[@VERSION]",
+		Short: "Show details of a package and its versions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a package")
+			}
+
+			pkg, ver := splitPackageVersion(args[0])
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			// Specific version is looked up first, to fail early
+			var version *api.Version
+			if ver != "" {
+				if version, err = c.Version(cc, pkg, ver); err != nil {
+					return err
+				}
+			}
+
+			versions := []*api.Version{}
+			err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+				resp, err := c.PackageVersions(cc, pkg, pageReq)
+				if err != nil {
+					return nil, err
+				}
+
+				versions = append(versions, resp.Versions...)
+				return resp.Pagination, nil
+			})
+			if err != nil {
+				return err
+			} else if len(versions) == 0 {
+				return fmt.Errorf("No versions found for %q", pkg)
+			}
+
+			info := newPackageInfo(pkg, versions, version)
+			if jsonFlag {
+				return termPrintJSON(term, info)
+			}
+
+			termPrintPackageInfo(term, info)
+			return nil
+		},
+	}
+
+	// Flags and options
+	infoCmd.Flags().BoolVar(&jsonFlag, "json", false, "Output as JSON")
+
+	return infoCmd
+}
+
+// packageInfo is the summary of a package for "info"
+type packageInfo struct {
+	*api.Package
+	Latest       string                 `json:"latest"`
+	VersionCount int                    `json:"version_count"`
+	TotalSize    int64                  `json:"total_size"`
+	Uploaders    []*packageInfoUploader `json:"uploaders"`
+	Versions     []*packageInfoVersion  `json:"versions"`
+}
+
+// packageInfoUploader is the upload history of one account
+type packageInfoUploader struct {
+	Name         string    `json:"name"`
+	Uploads      int       `json:"uploads"`
+	LastUploadAt time.Time `json:"last_upload_at"`
+}
+
+// packageInfoVersion is a version with its install snippet
+type packageInfoVersion struct {
+	*api.Version
+	Install string `json:"install,omitempty"`
+}
+
+// Summarize versions of a package, with details for one version, if given
+func newPackageInfo(name string, versions []*api.Version, only *api.Version) *packageInfo {
+	info := &packageInfo{Package: &api.Package{Name: name}}
+	if p := versions[0].Package; p != nil {
+		info.Package = p
+		if info.Name == "" {
+			info.Name = name
+		}
+	}
+
+	uploaders := map[string]*packageInfoUploader{}
+	var latest *api.Version
+
+	for _, v := range versions {
+		info.VersionCount++
+		info.TotalSize += v.Size
+
+		if latest == nil || v.CreatedAt.After(latest.CreatedAt) {
+			latest = v
+		}
+
+		by := v.DisplayCreatedBy()
+		u, ok := uploaders[by]
+		if !ok {
+			u = &packageInfoUploader{Name: by}
+			uploaders[by] = u
+			info.Uploaders = append(info.Uploaders, u)
+		}
+
+		u.Uploads++
+		if v.CreatedAt.After(u.LastUploadAt) {
+			u.LastUploadAt = v.CreatedAt
+		}
+	}
+
+	// Most recent uploaders first
+	sort.SliceStable(info.Uploaders, func(i, j int) bool {
+		return info.Uploaders[i].LastUploadAt.After(info.Uploaders[j].LastUploadAt)
+	})
+
+	info.Latest = info.LatestVersion.Version
+	if info.Latest == "" {
+		info.Latest = latest.Version
+	}
+
+	if only != nil {
+		versions = []*api.Version{only}
+	}
+
+	for _, v := range versions {
+		install := installSnippet(info.Kind, info.Name, v.Version)
+		info.Versions = append(info.Versions, &packageInfoVersion{v, install})
+	}
+
+	return info
+}
+
+func termPrintPackageInfo(term terminal.Terminal, info *packageInfo) {
+	uploaders := make([]string, 0, len(info.Uploaders))
+	for _, u := range info.Uploaders {
+		uploaders = append(uploaders, fmt.Sprintf("%s (%d)", u.Name, u.Uploads))
+	}
+
+	term.Printf("\n*** %s ***\n\n", info.Name)
+	w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "id:\t%s\n", info.ID)
+	fmt.Fprintf(w, "kind:\t%s\n", info.Kind)
+	fmt.Fprintf(w, "privacy:\t%s\n", info.Privacy())
+	fmt.Fprintf(w, "latest:\t%s\n", info.Latest)
+	fmt.Fprintf(w, "release:\t%s\n", info.DisplayVersion())
+	fmt.Fprintf(w, "versions:\t%d\n", info.VersionCount)
+	fmt.Fprintf(w, "total_size:\t%s\n", humanSize(info.TotalSize))
+	fmt.Fprintf(w, "uploaders:\t%s\n", strings.Join(uploaders, ", "))
+	w.Flush()
+
+	for _, v := range info.Versions {
+		term.Printf("\n*** %s %s ***\n\n", info.Name, v.Version.Version)
+		w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "filename:\t%s\n", v.Filename)
+		fmt.Fprintf(w, "uploaded_by:\t%s\n", v.DisplayCreatedBy())
+		fmt.Fprintf(w, "uploaded_at:\t%s\n", timeStringWithAgo(v.CreatedAt))
+		fmt.Fprintf(w, "size:\t%s\n", humanSize(v.Size))
+		if v.DownloadURL != "" {
+			fmt.Fprintf(w, "download_url:\t%s\n", v.DownloadURL)
+		}
+
+		d := v.Digests
+		for _, digest := range [][2]string{{"sha512", d.SHA512}, {"sha256", d.SHA256}, {"sha1", d.SHA1}, {"md5", d.MD5}} {
+			if digest[1] != "" {
+				fmt.Fprintf(w, "%s:\t%s\n", digest[0], digest[1])
+			}
+		}
+
+		if v.Install != "" {
+			fmt.Fprintf(w, "install:\t%s\n", v.Install)
+		}
+		w.Flush()
+	}
+}
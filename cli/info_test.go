@@ -0,0 +1,193 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const infoVersion = `{
+	"id": "ver_a1b2c3",
+	"version": "1.2.3",
+	"created_at": "2011-05-27T00:39:07+00:00",
+	"filename": "foo-1.2.3.tgz",
+	"size": 2048,
+	"download_url": "https://example.com/foo-1.2.3.tgz",
+	"digests": { "sha256": "abc256", "md5": "abc5" },
+	"created_by": { "name": "user1" },
+	"package": {
+		"id": "pkg_x9y8z7",
+		"name": "foo",
+		"kind_key": "js",
+		"private": true,
+		"latest_version": { "version": "1.2.3" },
+		"release_version": { "version": "1.2.3" }
+	}
+}`
+
+var infoVersionsResponses = []string{"[" + infoVersion + "]", `[{
+	"id": "ver_z1y2x3",
+	"version": "1.0.0",
+	"created_at": "2011-01-27T00:44:00+00:00",
+	"filename": "foo-1.0.0.tgz",
+	"size": 1024,
+	"created_by": { "name": "user2" },
+	"package": { "id": "pkg_x9y8z7", "name": "foo", "kind_key": "js" }
+}]`}
+
+// ==== info ====
+
+func TestInfoCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	server := testInfoServer(t)
+	defer server.Close()
+
+	summary := "*** foo *** id: pkg_x9y8z7 kind: js privacy: private latest: 1.2.3 release: 1.2.3 " +
+		"versions: 2 total_size: 3.0 KB uploaders: user1 (1), user2 (1) "
+	ver123 := "*** foo 1.2.3 *** filename: foo-1.2.3.tgz uploaded_by: user1 uploaded_at: 2011-05-26 17:39 " +
+		"size: 2.0 KB download_url: https://example.com/foo-1.2.3.tgz sha256: abc256 md5: abc5 " +
+		"install: npm install foo@1.2.3"
+	ver100 := " *** foo 1.0.0 *** filename: foo-1.0.0.tgz uploaded_by: user2 uploaded_at: 2011-01-26 16:44 " +
+		"size: 1.0 KB install: npm install foo@1.0.0"
+
+	// Each case is args and expected output
+	cases := []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"info", "foo"}, summary + ver123 + ver100},
+		{[]string{"info", "foo@1.2.3"}, summary + ver123},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommandNoErr(cc, c.args)
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := compactString(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
+	}
+}
+
+func TestInfoCommandJSON(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testInfoServer(t)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"info", "foo@1.2.3", "--json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := map[string]interface{}{}
+	if err := json.Unmarshal(term.OutBytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %s", err)
+	}
+
+	versions, _ := out["versions"].([]interface{})
+	if out["name"] != "foo" || out["version_count"] != float64(2) || out["total_size"] != float64(3072) {
+		t.Errorf("Unexpected summary: %v", out)
+	} else if len(versions) != 1 {
+		t.Errorf("Expected one version, got %v", versions)
+	} else if v := versions[0].(map[string]interface{}); v["install"] != "npm install foo@1.2.3" {
+		t.Errorf("Unexpected version: %v", v)
+	}
+}
+
+func TestInfoCommandNotFound(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServer(t, "GET", "/packages/bar/versions", "[]", 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommand(cc, []string{"info", "bar"})
+	if err == nil || !strings.Contains(err.Error(), `No versions found for "bar"`) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+func TestInfoCommandScopedPackage(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	paths := []string{}
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages/", func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.URL.Path)
+			if strings.HasSuffix(r.URL.Path, "/1.2.3") {
+				w.Write([]byte(infoVersion))
+			} else {
+				w.Write([]byte(infoVersionsResponses[0]))
+			}
+		})
+	})
+	defer server.Close()
+
+	// Each case is arg and expected request paths
+	cases := map[string]string{
+		"js:@scope/foo":       "/packages/js:@scope/foo/versions",
+		"js:@scope/foo@1.2.3": "/packages/js:@scope/foo/versions/1.2.3 /packages/js:@scope/foo/versions",
+		"@scope/foo@1.2.3":    "/packages/@scope/foo/versions/1.2.3 /packages/@scope/foo/versions",
+	}
+
+	for arg, exp := range cases {
+		cc := cli.TestContext(terminal.NewForTest(), auth)
+		ctx.GlobalFlags(cc).Endpoint = server.URL
+
+		paths = paths[:0]
+		if err := runCommandNoErr(cc, []string{"info", arg}); err != nil {
+			t.Fatalf("%s: %s", arg, err)
+		} else if got := strings.Join(paths, " "); got != exp {
+			t.Errorf("%s: Expected requests %q, got %q", arg, exp, got)
+		}
+	}
+}
+
+func TestInfoCommandUnauthorized(t *testing.T) {
+	server := testInfoServer(t)
+	testCommandLoginPreCheck(t, []string{"info", "foo"}, server)
+	server.Close()
+}
+
+func TestInfoCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/packages/foo/versions", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"info", "foo"}, server)
+	server.Close()
+}
+
+// Serves paginated versions of "foo", and its version 1.2.3
+func testInfoServer(t *testing.T) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages/foo/versions", func(w http.ResponseWriter, r *http.Request) {
+			testutil.APIPaginatedResponse(t, w, r, infoVersionsResponses, 200)
+		})
+		mux.HandleFunc("/packages/foo/versions/1.2.3", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(infoVersion))
+		})
+	})
+}
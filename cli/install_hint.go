@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"context"
+	"fmt"
+	"strings"
+)
+
+// NewCmdInstallHint generates the Cobra command for "install-hint"
+func NewCmdInstallHint() *cobra.Command {
+	var tokenFlag string
+
+	hintCmd := &cobra.Command{
+		Use:   "install-hint [KIND:]PACKAGE[@VERSION]",
+		Short: "Show how to install a package",
+		Long: `Show configuration and commands to install a package from this
+account, based on its kind. The kind is looked up unless KIND is given.
+
+Private packages need a read token, which is included with --token.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a package")
+			}
+
+			pkg, ver := splitPackageVersion(args[0])
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			kind, name := splitPackageKind(pkg)
+			if kind == "" {
+				if kind, err = packageKind(cc, c, name, ver); err != nil {
+					return err
+				}
+			}
+
+			account, err := currentAccountName(cc, c)
+			if err != nil {
+				return err
+			}
+
+			hint := installHint(kind, account, tokenFlag, name, ver)
+			if hint == "" {
+				return fmt.Errorf("No install hint for %q packages", kind)
+			}
+
+			term.Printf("%s", hint)
+			return nil
+		},
+	}
+
+	// Flags and options
+	hintCmd.Flags().StringVar(&tokenFlag, "token", "", "Read token to include in URLs")
+
+	return hintCmd
+}
+
+// Kind of package from the requested version, or any version
+func packageKind(cc context.Context, c *api.Client, pkg, ver string) (string, error) {
+	if ver != "" {
+		v, err := c.Version(cc, pkg, ver)
+		if err != nil {
+			return "", err
+		}
+		return v.Kind(), nil
+	}
+
+	resp, err := c.PackageVersions(cc, pkg, &api.PaginationRequest{Limit: 1})
+	if err != nil {
+		return "", err
+	} else if len(resp.Versions) == 0 {
+		return "", fmt.Errorf("No versions found for %q", pkg)
+	}
+
+	return resp.Versions[0].Kind(), nil
+}
+
+// Repository URL of an account, with token as username, if any
+func furyRepoURL(service, account, token, tokenSuffix string) string {
+	auth := ""
+	if token != "" {
+		auth = token + tokenSuffix + "@"
+	}
+	return fmt.Sprintf("https://%s%s.fury.io/%s/", auth, service, account)
+}
+
+// Configuration and install command for a package, by kind
+func installHint(kind, account, token, name, version string) string {
+	var b strings.Builder
+	install := installSnippet(kind, name, version)
+
+	switch kind {
+	case "python":
+		repo := furyRepoURL("pypi", account, token, "")
+		install = strings.Replace(install, "pip install ", "pip install --index-url "+repo+" ", 1)
+
+	case "js":
+		repo := furyRepoURL("npm", account, "", "")
+		b.WriteString("# .npmrc\n")
+		if at := strings.Index(name, "/"); strings.HasPrefix(name, "@") && at > 0 {
+			fmt.Fprintf(&b, "%s:registry=%s\n", name[0:at], repo)
+		} else {
+			fmt.Fprintf(&b, "registry=%s\n", repo)
+		}
+		if token != "" {
+			fmt.Fprintf(&b, "%s:_authToken=%s\n", strings.TrimPrefix(repo, "https:"), token)
+		}
+
+	case "ruby":
+		repo := furyRepoURL("gem", account, token, "")
+		b.WriteString("# Gemfile\n")
+		fmt.Fprintf(&b, "source %q do\n", repo)
+		if version != "" {
+			fmt.Fprintf(&b, "  gem %q, %q\n", name, version)
+		} else {
+			fmt.Fprintf(&b, "  gem %q\n", name)
+		}
+		b.WriteString("end\n")
+		install = "bundle install"
+
+	case "deb":
+		repo := furyRepoURL("apt", account, token, ":")
+		b.WriteString("# /etc/apt/sources.list.d/fury.list\n")
+		fmt.Fprintf(&b, "deb [trusted=yes] %s /\n", repo)
+		install = "sudo apt-get update && sudo " + install
+
+	case "rpm":
+		repo := furyRepoURL("yum", account, token, ":")
+		b.WriteString("# /etc/yum.repos.d/fury.repo\n")
+		b.WriteString("[fury]\nname=Gemfury Private Repo\n")
+		fmt.Fprintf(&b, "baseurl=%s\nenabled=1\ngpgcheck=0\n", repo)
+		install = "sudo " + install
+
+	case "nuget":
+		repo := furyRepoURL("nuget", account, "", "")
+		b.WriteString("# nuget.config\n")
+		b.WriteString("<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<configuration>\n")
+		b.WriteString("  <packageSources>\n")
+		fmt.Fprintf(&b, "    <add key=\"fury\" value=\"%s\" />\n", repo)
+		b.WriteString("  </packageSources>\n")
+		if token != "" {
+			b.WriteString("  <packageSourceCredentials>\n    <fury>\n")
+			fmt.Fprintf(&b, "      <add key=\"Username\" value=\"%s\" />\n", token)
+			b.WriteString("      <add key=\"ClearTextPassword\" value=\"NOPASS\" />\n")
+			b.WriteString("    </fury>\n  </packageSourceCredentials>\n")
+		}
+		b.WriteString("</configuration>\n")
+
+	case "maven":
+		repo := furyRepoURL("maven", account, token, "")
+		b.WriteString("# pom.xml\n<repositories>\n  <repository>\n")
+		fmt.Fprintf(&b, "    <id>fury</id>\n    <url>%s</url>\n", repo)
+		b.WriteString("  </repository>\n</repositories>\n")
+		if group, artifact, ok := strings.Cut(name, ":"); ok {
+			b.WriteString("<dependency>\n")
+			fmt.Fprintf(&b, "  <groupId>%s</groupId>\n  <artifactId>%s</artifactId>\n", group, artifact)
+			if version != "" {
+				fmt.Fprintf(&b, "  <version>%s</version>\n", version)
+			}
+			b.WriteString("</dependency>\n")
+		}
+
+	case "php":
+		repo := furyRepoURL("php", account, "", "")
+		b.WriteString("# composer.json\n")
+		fmt.Fprintf(&b, "\"repositories\": [{ \"type\": \"composer\", \"url\": %q }]\n", repo)
+		if token != "" {
+			b.WriteString("\n# auth.json\n")
+			fmt.Fprintf(&b, "\"http-basic\": { \"php.fury.io\": { \"username\": %q, \"password\": \"NOPASS\" } }\n", token)
+		}
+
+	case "go":
+		repo := furyRepoURL("go", account, token, "")
+		fmt.Fprintf(&b, "export GOPROXY=%s,https://proxy.golang.org,direct\n", repo)
+		fmt.Fprintf(&b, "export GONOSUMDB=%s\n", name)
+
+	default:
+		return ""
+	}
+
+	if install != "" {
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(install + "\n")
+	}
+
+	return b.String()
+}
+
+// Install command for a package, by kind, with optional version
+func installSnippet(kind, name, version string) string {
+	// Separator and version suffix, when a version is given
+	withVersion := func(sep string) string {
+		if version == "" {
+			return name
+		}
+		return name + sep + version
+	}
+
+	switch kind {
+	case "ruby":
+		return "gem install " + withVersion(" -v ")
+	case "js":
+		return "npm install " + withVersion("@")
+	case "python":
+		return "pip install " + withVersion("==")
+	case "nuget":
+		return "dotnet add package " + withVersion(" --version ")
+	case "go":
+		version = strings.TrimPrefix(version, "v")
+		return "go get " + withVersion("@v")
+	case "php":
+		return "composer require " + withVersion(":")
+	case "deb":
+		return "apt-get install " + withVersion("=")
+	case "rpm":
+		return "yum install " + withVersion("-")
+	}
+	return ""
+}
@@ -0,0 +1,127 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// ==== install-hint ====
+
+func TestInstallHintCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/users/me", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{ "name": "Joe Test", "username": "joetest" }`))
+		})
+		mux.HandleFunc("/packages/foo/versions", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(infoVersionsResponses[0]))
+		})
+		mux.HandleFunc("/packages/foo/versions/1.2.3", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(infoVersion))
+		})
+	})
+	defer server.Close()
+
+	// Each case is args and expected output
+	cases := []struct {
+		args []string
+		out  string
+	}{{
+		[]string{"foo"},
+		"# .npmrc\nregistry=https://npm.fury.io/joetest/\n\nnpm install foo\n",
+	}, {
+		[]string{"foo@1.2.3", "-a", "acme", "--token", "tok"},
+		"# .npmrc\nregistry=https://npm.fury.io/acme/\n//npm.fury.io/acme/:_authToken=tok\n\nnpm install foo@1.2.3\n",
+	}, {
+		[]string{"pip:bar@2.0", "-a", "acme", "--token", "tok"},
+		"pip install --index-url https://tok@pypi.fury.io/acme/ bar==2.0\n",
+	}, {
+		[]string{"ruby:bar@2.0", "-a", "acme"},
+		"# Gemfile\nsource \"https://gem.fury.io/acme/\" do\n  gem \"bar\", \"2.0\"\nend\n\nbundle install\n",
+	}, {
+		[]string{"deb:bar", "-a", "acme", "--token", "tok"},
+		"# /etc/apt/sources.list.d/fury.list\ndeb [trusted=yes] https://tok:@apt.fury.io/acme/ /\n\n" +
+			"sudo apt-get update && sudo apt-get install bar\n",
+	}, {
+		[]string{"go:example.com/bar@1.0.0", "-a", "acme"},
+		"export GOPROXY=https://go.fury.io/acme/,https://proxy.golang.org,direct\n" +
+			"export GONOSUMDB=example.com/bar\n\ngo get example.com/bar@v1.0.0\n",
+	}}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommandNoErr(cc, append([]string{"install-hint"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := string(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
+	}
+}
+
+func TestInstallHintCommandConfigFiles(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	// Each case is args and expected content of output
+	cases := []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"rpm:bar"}, "baseurl=https://tok:@yum.fury.io/acme/\n"},
+		{[]string{"js:@scope/bar"}, "@scope:registry=https://npm.fury.io/acme/\n"},
+		{[]string{"js:@scope/bar@1.0"}, "\nnpm install @scope/bar@1.0\n"},
+		{[]string{"nuget:Bar"}, `<add key="Username" value="tok" />`},
+		{[]string{"maven:com.example:bar@1.0"}, "<url>https://tok@maven.fury.io/acme/</url>"},
+		{[]string{"maven:com.example:bar@1.0"}, "<artifactId>bar</artifactId>\n  <version>1.0</version>"},
+		{[]string{"php:acme/bar@1.0"}, `"url": "https://php.fury.io/acme/"`},
+		{[]string{"php:acme/bar@1.0"}, `"username": "tok"`},
+		{[]string{"php:acme/bar@1.0"}, "\ncomposer require acme/bar:1.0\n"},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+
+		args := append([]string{"install-hint", "-a", "acme", "--token", "tok"}, c.args...)
+		if err := runCommandNoErr(cc, args); err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := string(term.OutBytes()); !strings.Contains(outStr, c.out) {
+			t.Errorf("%v: Expected output to include %q, got %q", c.args, c.out, outStr)
+		}
+	}
+
+	// Unsupported kind
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, auth)
+	err := runCommand(cc, []string{"install-hint", "-a", "acme", "conda:bar"})
+	if err == nil || err.Error() != `No install hint for "conda" packages` {
+		t.Errorf("Expected unsupported error, got %v", err)
+	}
+}
+
+func TestInstallHintCommandUnauthorized(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/packages/foo/versions", infoVersionsResponses[0], 200)
+	testCommandLoginPreCheck(t, []string{"install-hint", "-a", "acme", "foo"}, server)
+	server.Close()
+}
+
+func TestInstallHintCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/packages/foo/versions", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"install-hint", "foo"}, server)
+	server.Close()
+}
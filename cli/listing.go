@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// listOptions are the flags for sorting and paging of listings
+type listOptions struct {
+	Sort      string
+	Reverse   bool
+	Limit     int
+	Kind      string
+	Page      string
+	JSONLines bool
+}
+
+// addListFlags adds sorting and paging flags, and "--kind" if requested
+func addListFlags(cmd *cobra.Command, opts *listOptions, sorts []string, withKind bool) {
+	flags := cmd.Flags()
+	flags.StringVar(&opts.Sort, "sort", "", "Sort by "+strings.Join(sorts, ", "))
+	flags.BoolVar(&opts.Reverse, "reverse", false, "Reverse order of results")
+	flags.IntVar(&opts.Limit, "limit", 0, "Stop after this many results")
+	flags.StringVar(&opts.Page, "page", "", "Resume listing from page cursor")
+	if withKind {
+		flags.StringVar(&opts.Kind, "kind", "", "Filter to one kind of package")
+	}
+}
+
+// Check options before fetching anything
+func (o listOptions) validate(sorts []string) error {
+	if o.Limit < 0 {
+		return fmt.Errorf("Invalid --limit %d", o.Limit)
+	} else if o.Limit > 0 && (o.Sort != "" || o.Reverse) {
+		// Sorting the first results only would look like the top ones
+		return fmt.Errorf("Sorting needs all results, so --limit can't be used with --sort or --reverse")
+	}
+
+	if o.Sort == "" {
+		return nil
+	}
+
+	for _, s := range sorts {
+		if s == o.Sort {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("Unknown sort %q. Available: %s", o.Sort, strings.Join(sorts, ", "))
+}
+
+// Rows can be printed as pages arrive, unless they need sorting
+func (o listOptions) streaming() bool {
+	return o.Sort == "" && !o.Reverse
+}
+
+// Kind from "--kind", with common names like "npm" resolved
+func (o listOptions) kind() string {
+	if alias, ok := kindAliases[o.Kind]; ok {
+		return alias
+	}
+	return o.Kind
+}
+
+// sortListing orders items by the "--sort" option, then "--reverse"
+func sortListing[T any](items []T, opts listOptions, sorts map[string]func(a, b T) int) {
+	if cmp, ok := sorts[opts.Sort]; ok {
+		sort.SliceStable(items, func(i, j int) bool {
+			return cmp(items[i], items[j]) < 0
+		})
+	}
+
+	if opts.Reverse {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+}
+
+// finishListing tells how to continue a listing stopped by "--limit"
+// or Ctrl-C. Interrupted listings aren't usage errors.
+func finishListing(cmd *cobra.Command, next string, err error) error {
+	cc := cmd.Context()
+	if next != "" {
+		fmt.Fprintf(ctx.Terminal(cc).IOErr(), "More results with: --page %s\n", next)
+	}
+
+	if cc.Err() != nil {
+		cmd.SilenceUsage = true
+	}
+
+	return err
+}
+
+// Names of sorts for flags and validation
+func listSortNames[T any](sorts map[string]func(a, b T) int) []string {
+	names := make([]string, 0, len(sorts))
+	for name := range sorts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// streamColumn is a column of a streamTable
+type streamColumn struct {
+	name  string
+	width int
+}
+
+// streamTable prints rows as they arrive. Unlike tabwriter, columns have
+// fixed widths, since rows of later pages aren't known when printing.
+type streamTable struct {
+	w       io.Writer
+	title   string
+	columns []streamColumn
+	rows    int
+}
+
+func newStreamTable(w io.Writer, title string, columns ...streamColumn) *streamTable {
+	return &streamTable{w: w, title: title, columns: columns}
+}
+
+// Row prints a row, after the title and header for the first one
+func (t *streamTable) Row(values ...string) {
+	if t.rows == 0 {
+		t.Header()
+	}
+	t.rows++
+	t.write(values)
+}
+
+// Header prints the title and column names
+func (t *streamTable) Header() {
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	io.WriteString(t.w, t.title)
+	t.write(names)
+}
+
+// Longer values push later columns over, rather than being truncated
+func (t *streamTable) write(values []string) {
+	var b strings.Builder
+	for i, v := range values {
+		if i < len(values)-1 {
+			fmt.Fprintf(&b, "%-*s  ", t.columns[i].width, v)
+		} else {
+			b.WriteString(v)
+		}
+	}
+	b.WriteString("\n")
+	io.WriteString(t.w, b.String())
+}
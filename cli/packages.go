@@ -8,6 +8,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -17,24 +18,65 @@ import (
 
 // NewCmdPackages creates the "packages" command
 func NewCmdPackages() *cobra.Command {
-	return &cobra.Command{
+	opts := listOptions{}
+
+	packagesCmd := &cobra.Command{
 		Use:     "packages",
 		Aliases: []string{"list"},
 		Short:   "List packages in this account",
-		RunE:    listPackages,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listPackages(cmd, args, opts)
+		},
 	}
+
+	// Flags and options
+	addListFlags(packagesCmd, &opts, listSortNames(packageSorts), true)
+	packagesCmd.Flags().BoolVar(&opts.JSONLines, "jsonl", false, "Output as JSON Lines")
+
+	packagesCmd.AddCommand(NewCmdPackagesPrivacy())
+
+	return packagesCmd
 }
 
 // NewCmdVersions creates the "versions" command
 func NewCmdVersions() *cobra.Command {
-	return &cobra.Command{
+	opts := listOptions{}
+
+	versionsCmd := &cobra.Command{
 		Use:   "versions PACKAGE",
 		Short: "List versions for a package",
-		RunE:  listVersions,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listVersions(cmd, args, opts)
+		},
 	}
+
+	// Flags and options
+	addListFlags(versionsCmd, &opts, listSortNames(versionSorts), false)
+	versionsCmd.Flags().BoolVar(&opts.JSONLines, "jsonl", false, "Output as JSON Lines")
+
+	return versionsCmd
 }
 
-func listPackages(cmd *cobra.Command, args []string) error {
+// Sorting of "packages", by name of "--sort" option
+var packageSorts = map[string]func(a, b *api.Package) int{
+	"name":    func(a, b *api.Package) int { return strings.Compare(a.Name, b.Name) },
+	"kind":    func(a, b *api.Package) int { return strings.Compare(a.Kind, b.Kind) },
+	"version": func(a, b *api.Package) int { return compareVersions(a.LatestVersion.Version, b.LatestVersion.Version) },
+	"created": func(a, b *api.Package) int { return a.LatestVersion.CreatedAt.Compare(b.LatestVersion.CreatedAt) },
+}
+
+// Sorting of "versions", by name of "--sort" option
+var versionSorts = map[string]func(a, b *api.Version) int{
+	"name":    func(a, b *api.Version) int { return strings.Compare(a.Filename, b.Filename) },
+	"version": func(a, b *api.Version) int { return compareVersions(a.Version, b.Version) },
+	"created": func(a, b *api.Version) int { return a.CreatedAt.Compare(b.CreatedAt) },
+}
+
+func listPackages(cmd *cobra.Command, args []string, opts listOptions) error {
+	if err := opts.validate(listSortNames(packageSorts)); err != nil {
+		return err
+	}
+
 	cc := cmd.Context()
 	term := ctx.Terminal(cc)
 	c, err := newAPIClient(cc)
@@ -43,40 +85,91 @@ func listPackages(cmd *cobra.Command, args []string) error {
 	}
 
 	packages := []*api.Package{}
+	kind := opts.kind()
+
+	// Rows are printed as pages arrive, unless sorted
+	table := newStreamTable(term.IOOut(), "\n*** GEMFURY PACKAGES ***\n\n", packageColumns...)
+	enc := json.NewEncoder(term.IOOut())
+	emit := func(p *api.Package) error {
+		if !opts.streaming() {
+			packages = append(packages, p)
+		} else if opts.JSONLines {
+			return enc.Encode(p)
+		} else {
+			table.Row(packageRow(p)...)
+		}
+		return nil
+	}
 
-	// Paginate over package listings until no more pages
-	err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+	// Paginate over package listings until no more pages, or limit
+	count := 0
+	next, err := iteratePages(cc, !opts.streaming(), opts.Page, opts.Limit, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, int, error) {
 		resp, err := c.Packages(cc, pageReq)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+
+		pageCount := 0
+		for _, p := range resp.Packages {
+			if kind == "" || p.Kind == kind {
+				if err := emit(p); err != nil {
+					return nil, 0, err
+				}
+				pageCount++
+			}
 		}
 
-		packages = append(packages, resp.Packages...)
-		return resp.Pagination, nil
+		count += pageCount
+		return resp.Pagination, pageCount, nil
 	})
 
 	// Handle no packages
-	if len(packages) == 0 {
-		term.Println("No packages found in this account")
-		return err
+	if count == 0 && !opts.JSONLines {
+		if opts.Kind != "" {
+			term.Printf("No %s packages found in this account\n", opts.Kind)
+		} else {
+			term.Println("No packages found in this account")
+		}
+		return finishListing(cmd, next, err)
 	}
 
-	// Print results
-	term.Printf("\n*** GEMFURY PACKAGES ***\n\n")
-	w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "name\tkind\tversion\tprivacy\n")
+	sortListing(packages, opts, packageSorts)
 
-	for _, p := range packages {
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", p.Name, p.Kind, p.DisplayVersion(), p.Privacy())
+	// Print sorted results
+	if opts.JSONLines {
+		for _, p := range packages {
+			if jerr := enc.Encode(p); jerr != nil {
+				return jerr
+			}
+		}
+	} else if len(packages) > 0 {
+		term.Printf("\n*** GEMFURY PACKAGES ***\n\n")
+		w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "name\tkind\tversion\tprivacy\n")
+
+		for _, p := range packages {
+			fmt.Fprintf(w, "%s\n", strings.Join(packageRow(p), "\t"))
+		}
+
+		w.Flush()
 	}
 
-	w.Flush()
-	return err
+	return finishListing(cmd, next, err)
 }
 
-func listVersions(cmd *cobra.Command, args []string) error {
+var packageColumns = []streamColumn{
+	{"name", 30}, {"kind", 8}, {"version", 14}, {"privacy", 0},
+}
+
+func packageRow(p *api.Package) []string {
+	return []string{p.Name, p.Kind, p.DisplayVersion(), p.Privacy()}
+}
+
+func listVersions(cmd *cobra.Command, args []string, opts listOptions) error {
 	if len(args) != 1 {
 		return fmt.Errorf("Please specify a package")
+	} else if err := opts.validate(listSortNames(versionSorts)); err != nil {
+		return err
 	}
 
 	cc := cmd.Context()
@@ -87,22 +180,64 @@ func listVersions(cmd *cobra.Command, args []string) error {
 	}
 
 	versions := []*api.Version{}
+	title := fmt.Sprintf("\n*** %s versions ***\n\n", args[0])
+
+	// Rows are printed as pages arrive, unless sorted
+	table := newStreamTable(term.IOOut(), title, versionColumns...)
+	enc := json.NewEncoder(term.IOOut())
+	emit := func(v *api.Version) error {
+		if !opts.streaming() {
+			versions = append(versions, v)
+		} else if opts.JSONLines {
+			return enc.Encode(v)
+		} else {
+			table.Row(versionRow(v)...)
+		}
+		return nil
+	}
 
-	// Paginate over package listings until no more pages
-	err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+	// Paginate over package listings until no more pages, or limit
+	next, err := iteratePages(cc, !opts.streaming(), opts.Page, opts.Limit, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, int, error) {
 		resp, err := c.PackageVersions(cc, args[0], pageReq)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 
-		versions = append(versions, resp.Versions...)
-		return resp.Pagination, nil
+		for _, v := range resp.Versions {
+			if err := emit(v); err != nil {
+				return nil, 0, err
+			}
+		}
+
+		return resp.Pagination, len(resp.Versions), nil
 	})
 
-	// Print results
-	term.Printf("\n*** %s versions ***\n\n", args[0])
-	termPrintVersions(term, versions)
-	return err
+	sortListing(versions, opts, versionSorts)
+
+	// Print sorted results, or header of empty streamed table
+	if opts.JSONLines {
+		for _, v := range versions {
+			if jerr := enc.Encode(v); jerr != nil {
+				return jerr
+			}
+		}
+	} else if !opts.streaming() {
+		term.Printf("%s", title)
+		termPrintVersions(term, versions)
+	} else if table.rows == 0 {
+		table.Header()
+	}
+
+	return finishListing(cmd, next, err)
+}
+
+var versionColumns = []streamColumn{
+	{"version", 14}, {"uploaded_by", 14}, {"uploaded_at", 28}, {"kind", 8}, {"filename", 0},
+}
+
+func versionRow(v *api.Version) []string {
+	uploadedAt := timeStringWithAgo(v.CreatedAt)
+	return []string{v.Version, v.DisplayCreatedBy(), uploadedAt, v.Kind(), v.Filename}
 }
 
 func termPrintVersions(term terminal.Terminal, versions []*api.Version) {
@@ -110,8 +245,7 @@ func termPrintVersions(term terminal.Terminal, versions []*api.Version) {
 	fmt.Fprintf(w, "version\tuploaded_by\tuploaded_at\tkind\tfilename\n")
 
 	for _, v := range versions {
-		uploadedAt := timeStringWithAgo(v.CreatedAt)
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v.Version, v.DisplayCreatedBy(), uploadedAt, v.Kind(), v.Filename)
+		fmt.Fprintf(w, "%s\n", strings.Join(versionRow(v), "\t"))
 	}
 
 	w.Flush()
@@ -122,9 +256,19 @@ func iterateAllPages(cc context.Context, fn func(req *api.PaginationRequest) (*a
 }
 
 func iterateAll(cc context.Context, showSpinner bool, fn func(req *api.PaginationRequest) (*api.PaginationResponse, error)) error {
+	_, err := iteratePages(cc, showSpinner, "", 0, func(req *api.PaginationRequest) (*api.PaginationResponse, int, error) {
+		resp, err := fn(req)
+		return resp, 0, err
+	})
+	return err
+}
+
+// iteratePages is iterateAll starting from a page cursor, and stopping once
+// fn has counted limit results. Returns the cursor of the next page, if any.
+func iteratePages(cc context.Context, showSpinner bool, page string, limit int, fn func(req *api.PaginationRequest) (*api.PaginationResponse, int, error)) (string, error) {
 	term := ctx.Terminal(cc)
 	pageReq := api.PaginationRequest{
-		Limit: 100,
+		Page: page,
 	}
 
 	var spin *spinner.Spinner
@@ -135,10 +279,18 @@ func iterateAll(cc context.Context, showSpinner bool, fn func(req *api.Paginatio
 		}
 	}()
 
-	for {
-		pageResp, err := fn(&pageReq)
-		if err != nil {
-			return err
+	for total := 0; ; {
+		// Pages never go past the limit, so the next cursor loses nothing
+		pageReq.Limit = 100
+		if remaining := limit - total; limit > 0 && remaining < pageReq.Limit {
+			pageReq.Limit = remaining
+		}
+
+		pageResp, count, err := fn(&pageReq)
+		if err != nil && cc.Err() != nil {
+			return pageReq.Page, cc.Err() // Interrupted, resume from this page
+		} else if err != nil {
+			return "", err
 		}
 
 		pageReq.Page = ""
@@ -152,10 +304,16 @@ func iterateAll(cc context.Context, showSpinner bool, fn func(req *api.Paginatio
 			}
 		}
 
-		if pageReq.Page == "" || cc.Err() != nil {
+		if total += count; limit > 0 && total >= limit {
+			return pageReq.Page, nil
+		}
+
+		if pageReq.Page == "" {
 			break
+		} else if err := cc.Err(); err != nil {
+			return pageReq.Page, err
 		}
 	}
 
-	return nil
+	return "", nil
 }
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/spf13/cobra"
+
+	"fmt"
+)
+
+// NewCmdPackagesPrivacy makes a package public or private
+func NewCmdPackagesPrivacy() *cobra.Command {
+	var forceFlag bool
+
+	privacyCmd := &cobra.Command{
+		Use:   "privacy PACKAGE public|private",
+		Short: "Make a package public or private",
+		Long: `Make a package public or private.
+
+Public packages can be downloaded by anyone without authentication,
+so making a package public asks for confirmation unless --force.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				return fmt.Errorf("Please specify a package and public or private")
+			}
+
+			pkg, privacy := args[0], args[1]
+			if privacy != "public" && privacy != "private" {
+				return fmt.Errorf("Invalid privacy %q, use public or private", privacy)
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			if privacy == "public" && !forceFlag {
+				confirm := fmt.Sprintf("Make %q public? Anyone will be able to download it [y/N]", pkg)
+				if ok, err := terminal.PromptConfirm(term, confirm); !ok {
+					return err
+				}
+			}
+
+			err = c.PackageSetPrivacy(cc, pkg, privacy == "private")
+			if err != nil {
+				return err
+			}
+
+			term.Printf("Package %q is now %s\n", pkg, privacy)
+			return nil
+		},
+	}
+
+	// Flags and options
+	privacyCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+
+	return privacyCmd
+}
@@ -1,10 +1,19 @@
 package cli_test
 
 import (
+	"github.com/gemfury/cli/api"
 	"github.com/gemfury/cli/cli"
 	"github.com/gemfury/cli/internal/ctx"
 	"github.com/gemfury/cli/internal/testutil"
 	"github.com/gemfury/cli/pkg/terminal"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strings"
 	"testing"
 )
@@ -50,6 +59,75 @@ func TestPackagesCommandSuccess(t *testing.T) {
 	}
 }
 
+func TestPackagesCommandListOptions(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	limits := []int{}
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			pageReq := api.PaginationRequest{}
+			json.Unmarshal(body, &pageReq)
+			limits = append(limits, pageReq.Limit)
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			testutil.APIPaginatedResponse(t, w, r, packagesResponses, 200)
+		})
+	})
+	defer server.Close()
+
+	// Each case is args, expected output, next page, and page limits
+	cases := []struct {
+		args   []string
+		out    string
+		next   string
+		limits []int
+	}{
+		{[]string{"--sort", "name"}, "pkg-js js beta private pkg-ruby ruby 1.1.1 public", "", []int{100, 100}},
+		{[]string{"--sort", "kind", "--reverse"}, "pkg-ruby ruby 1.1.1 public pkg-js js beta private", "", []int{100, 100}},
+		{[]string{"--reverse"}, "pkg-js js beta private pkg-ruby ruby 1.1.1 public", "", []int{100, 100}},
+		{[]string{"--kind", "npm"}, "pkg-js js beta private", "", []int{100, 100}},
+		{[]string{"--kind", "deb"}, "No deb packages found in this account", "", []int{100, 100}},
+		{[]string{"--limit", "1"}, "pkg-ruby ruby 1.1.1 public", "More results with: --page p\n", []int{1}},
+		{[]string{"--page", "p"}, "pkg-js js beta private", "", []int{100}},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		limits = limits[:0]
+		err := runCommand(cc, append([]string{"packages"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, c.out) {
+			t.Errorf("%v: Expected output to include %q, got %q", c.args, c.out, outStr)
+		} else if errStr := string(term.ErrBytes()); errStr != c.next {
+			t.Errorf("%v: Expected stderr %q, got %q", c.args, c.next, errStr)
+		} else if fmt.Sprint(limits) != fmt.Sprint(c.limits) {
+			t.Errorf("%v: Expected page limits %v, got %v", c.args, c.limits, limits)
+		}
+	}
+
+	// Invalid options
+	invalid := map[string][]string{
+		`Unknown sort "size". Available: created, kind, name, version`:                 {"--sort", "size"},
+		"Sorting needs all results, so --limit can't be used with --sort or --reverse": {"--sort", "name", "--limit", "1"},
+	}
+
+	for exp, args := range invalid {
+		cc := cli.TestContext(terminal.NewForTest(), auth)
+		err := runCommand(cc, append([]string{"packages"}, args...))
+		if err == nil || err.Error() != exp {
+			t.Errorf("%v: Expected error %q, got %v", args, exp, err)
+		}
+	}
+}
+
 func TestPackagesCommandUnauthorized(t *testing.T) {
 	server := testutil.APIServer(t, "GET", "/packages", "[]", 200)
 	testCommandLoginPreCheck(t, []string{"packages"}, server)
@@ -62,6 +140,76 @@ func TestPackagesCommandForbidden(t *testing.T) {
 	server.Close()
 }
 
+// ==== packages privacy ====
+
+func TestPackagesPrivacyCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	requests := []string{}
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages/{name}", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.String())
+			w.Write([]byte("{}"))
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Making private needs no confirmation
+	err := runCommandNoErr(cc, []string{"packages", "privacy", "pkg-js", "private"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "Package \"pkg-js\" is now private\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// Declining to make public sends no request
+	term.SetPromptResponses(map[string]string{
+		`Make "pkg-js" public? Anyone will be able to download it [y/N]`: "ABORT",
+	})
+	if err := runCommandNoErr(cc, []string{"packages", "privacy", "pkg-js", "public"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Confirming makes it public
+	term.SetPromptResponses(map[string]string{
+		`Make "pkg-js" public? Anyone will be able to download it [y/N]`: "Y",
+	})
+	if err := runCommandNoErr(cc, []string{"packages", "privacy", "pkg-js", "public"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expReqs := "PATCH /packages/pkg-js?package[private]=true, PATCH /packages/pkg-js?package[private]=false"
+	if r := strings.Join(requests, ", "); r != expReqs {
+		t.Errorf("Expected requests %q, got %q", expReqs, r)
+	}
+
+	// Invalid privacy value
+	err = runCommandNoErr(cc, []string{"packages", "privacy", "pkg-js", "secret"})
+	if err == nil || !strings.Contains(err.Error(), "Invalid privacy") {
+		t.Errorf("Expected invalid error, got %v", err)
+	}
+}
+
+func TestPackagesPrivacyCommandUnauthorized(t *testing.T) {
+	server := testutil.APIServer(t, "PATCH", "/packages/pkg-js", "{}", 200)
+	testCommandLoginPreCheck(t, []string{"packages", "privacy", "pkg-js", "private"}, server)
+	server.Close()
+}
+
+func TestPackagesPrivacyCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "PATCH", "/packages/pkg-js", "", 403)
+	testCommandForbiddenResponse(t, []string{"packages", "privacy", "pkg-js", "private"}, server)
+	server.Close()
+}
+
 // ==== versions ====
 
 var versionsResponses = []string{`[{
@@ -111,6 +259,181 @@ func TestVersionsCommandSuccess(t *testing.T) {
 	}
 }
 
+func TestVersionsCommandListOptions(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	path := "/packages/pkg-name/versions"
+	server := testutil.APIServerPaginated(t, "GET", path, versionsResponses, 200)
+	defer server.Close()
+
+	// Each case is args and expected versions, in order
+	cases := []struct {
+		args []string
+		out  string
+	}{
+		{[]string{"--sort", "version"}, "1.2.3 3.2.1"},
+		{[]string{"--sort", "created"}, "3.2.1 1.2.3"},
+		{[]string{"--sort", "name", "--reverse"}, "3.2.1 1.2.3"},
+		{[]string{"--limit", "1"}, "1.2.3"},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommand(cc, append([]string{"versions", "pkg-name"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		versions := []string{}
+		for _, line := range strings.Split(string(term.OutBytes()), "\n") {
+			if f := strings.Fields(line); len(f) > 0 && strings.Contains(f[0], ".") {
+				versions = append(versions, f[0])
+			}
+		}
+
+		if out := strings.Join(versions, " "); out != c.out {
+			t.Errorf("%v: Expected versions %q, got %q", c.args, c.out, out)
+		}
+	}
+}
+
+func TestVersionsCommandStreaming(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	// Second page is requested after first page is printed
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages/pkg-name/versions", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if pageReq := (api.PaginationRequest{}); json.Unmarshal(body, &pageReq) == nil && pageReq.Page != "" {
+				if out := string(term.OutBytes()); !strings.Contains(out, "foo-1.2.3.tgz") {
+					t.Errorf("Expected first page before second request, got %q", out)
+				}
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			testutil.APIPaginatedResponse(t, w, r, versionsResponses, 200)
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"versions", "pkg-name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Fixed columns, since widths of later rows aren't known
+	exp := "\n*** pkg-name versions ***\n\n" +
+		"version         uploaded_by     uploaded_at                   kind      filename\n" +
+		"1.2.3           user1           2011-05-26 17:39              js        foo-1.2.3.tgz\n" +
+		"3.2.1           N/A             2011-01-26 16:44              js        foo-3.2.1.tgz\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	// JSON Lines, one version per line
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, auth)
+	ctx.GlobalFlags(cc).Endpoint = server.URL
+
+	if err := runCommandNoErr(cc, []string{"versions", "pkg-name", "--jsonl"}); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(term.OutBytes())), "\n")
+	for i, id := range []string{"ver_a1b2c3", "ver_z1y2x3"} {
+		v := api.Version{}
+		if len(lines) != 2 {
+			t.Fatalf("Expected 2 lines, got %q", lines)
+		} else if err := json.Unmarshal([]byte(lines[i]), &v); err != nil || v.ID != id {
+			t.Errorf("Expected version %q, got %q (%v)", id, lines[i], err)
+		}
+	}
+}
+
+func TestVersionsCommandInterrupted(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	cc, cancel := context.WithCancel(cli.TestContext(term, auth))
+	defer cancel()
+
+	// Ctrl-C while second page is requested
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/packages/pkg-name/versions", func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			if pageReq := (api.PaginationRequest{}); json.Unmarshal(body, &pageReq) == nil && pageReq.Page != "" {
+				cancel()
+				<-r.Context().Done()
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			testutil.APIPaginatedResponse(t, w, r, versionsResponses, 200)
+		})
+	})
+	defer server.Close()
+
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommand(cc, []string{"versions", "pkg-name"})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected canceled error, got %v", err)
+	}
+
+	exp := "1.2.3 user1 2011-05-26 17:39 js foo-1.2.3.tgz"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+
+	expErr := "More results with: --page p\n"
+	if errStr := string(term.ErrBytes()); !strings.HasPrefix(errStr, expErr) {
+		t.Errorf("Expected error output %q, got %q", expErr, errStr)
+	}
+}
+
+func TestVersionsCommandJSONLinesClosedPipe(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	path := "/packages/pkg-name/versions"
+	server := testutil.APIServerPaginated(t, "GET", path, versionsResponses, 200)
+	defer server.Close()
+
+	// Streamed and sorted output both fail on a closed pipe
+	for _, args := range [][]string{{"--jsonl"}, {"--jsonl", "--sort", "version"}} {
+		pr, pw := io.Pipe()
+		pr.Close()
+
+		term := closedOutTerm{terminal.NewForTest(), pw}
+		cc := cli.TestContext(term, auth)
+		ctx.GlobalFlags(cc).Endpoint = server.URL
+
+		err := runCommand(cc, append([]string{"versions", "pkg-name"}, args...))
+		if !errors.Is(err, io.ErrClosedPipe) {
+			t.Errorf("%v: Expected closed pipe error, got %v", args, err)
+		}
+	}
+}
+
+// Test terminal with output to a closed pipe
+type closedOutTerm struct {
+	terminal.Terminal
+	out io.Writer
+}
+
+func (t closedOutTerm) IOOut() io.Writer {
+	return t.out
+}
+
 func TestVersionsCommandUnauthorized(t *testing.T) {
 	path := "/packages/pkg-name/versions"
 	server := testutil.APIServer(t, "GET", path, "[]", 200)
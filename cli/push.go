@@ -5,27 +5,52 @@ import (
 	"github.com/gemfury/cli/internal/ctx"
 	"github.com/hashicorp/go-multierror"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"context"
+	"crypto/sha512"
 	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"strings"
+)
+
+var (
+	errPushChecksum = errors.New("Uploaded checksum mismatch")
 )
 
 // NewCmdPush generates the Cobra command for "push"
 func NewCmdPush() *cobra.Command {
+	var manifestFlag string
 	var noProgress bool
 	var isPublic bool
+	var verify bool
 
 	pushCmd := &cobra.Command{
 		Use:   "push PACKAGE",
 		Short: "Upload a new version of a package",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			if len(args) == 0 {
+			if len(args) == 0 && manifestFlag == "" {
 				return fmt.Errorf("Please specify at least one package")
 			}
 
+			// Files from command line share the same options
+			entries := make([]pushEntry, 0, len(args))
+			for _, path := range args {
+				entries = append(entries, pushEntry{Path: path})
+			}
+
+			// Files from manifest have individual options
+			if manifestFlag != "" {
+				manifest, err := readPushManifest(manifestFlag)
+				if err != nil {
+					return err
+				}
+				entries = append(entries, manifest...)
+			}
+
 			cc := cmd.Context()
 			term := ctx.Terminal(cc)
 			c, err := newAPIClient(cc)
@@ -35,33 +60,23 @@ func NewCmdPush() *cobra.Command {
 
 			// Upload each file and collect errors
 			var multiErr *multierror.Error
-			for _, path := range args {
-				name := filepath.Base(path)
+			for _, e := range entries {
+				name := filepath.Base(e.Path)
 				prefix := fmt.Sprintf("Uploading %s ", name)
 
-				err := func() error {
-					file, err := os.Open(path)
-					if err != nil {
-						return err
-					}
-					defer file.Close()
-
-					// Prepare progress bar
-					var reader io.Reader = file
-					if noProgress {
-						term.Printf(prefix)
-						prefix = ""
-					} else {
-						stat, _ := file.Stat()
-						bar := term.StartProgress(stat.Size(), prefix)
-						reader = bar.NewProxyReader(file)
-						defer bar.Finish()
-					}
+				// Each file may target another account
+				client := c
+				if e.Account != "" {
+					prefix = fmt.Sprintf("Uploading %s to %s ", name, e.Account)
+					client = c.WithAccount(e.Account)
+				}
 
-					err = c.PushPkg(cc, name, isPublic, reader)
-					return err
-				}()
+				opts := api.PushOptions{Public: isPublic, Kind: e.Kind}
+				if e.Public != nil {
+					opts.Public = *e.Public
+				}
 
+				err := pushFile(cc, client, e.Path, opts, &prefix, noProgress, verify)
 				if err != nil {
 					multiErr = multierror.Append(multiErr, err)
 				}
@@ -70,6 +85,8 @@ func NewCmdPush() *cobra.Command {
 					term.Printf("%s- done\n", prefix)
 				} else if os.IsNotExist(err) {
 					term.Printf("%s- file not found\n", prefix)
+				} else if errors.Is(err, errPushChecksum) {
+					term.Printf("%s- CHECKSUM MISMATCH\n", prefix)
 				} else if errors.Is(err, api.ErrUnauthorized) {
 					term.Printf("%s- unauthorized\n", prefix)
 				} else if errors.Is(err, api.ErrForbidden) {
@@ -87,6 +104,18 @@ func NewCmdPush() *cobra.Command {
 				multiErr.ErrorFormat = func([]error) string {
 					return "There was a problem uploading at least 1 package"
 				}
+
+				// Checksum mismatches must not go unnoticed
+				for _, err := range multiErr.Errors {
+					if errors.Is(err, errPushChecksum) {
+						fmt.Fprintf(term.IOErr(), "ERROR: %s\n", err)
+					}
+				}
+
+				// Consolidated report for multi-file uploads
+				if n := len(multiErr.Errors); len(entries) > 1 {
+					term.Printf("Failed to upload %d of %d files\n", n, len(entries))
+				}
 			}
 
 			return multiErr.Unwrap()
@@ -96,6 +125,88 @@ func NewCmdPush() *cobra.Command {
 	// Flags and options
 	pushCmd.Flags().BoolVar(&noProgress, "quiet", false, "Do not show progress bar")
 	pushCmd.Flags().BoolVar(&isPublic, "public", false, "Create as public package")
+	pushCmd.Flags().BoolVar(&verify, "verify", false, "Verify uploaded checksum")
+	pushCmd.Flags().StringVar(&manifestFlag, "manifest", "", "Upload files listed in a YAML manifest")
 
 	return pushCmd
 }
+
+// Upload one file, optionally verifying checksum reported by the server.
+// Without progress bar, prefix is cleared once printed for the result line.
+func pushFile(cc context.Context, c *api.Client, path string, opts api.PushOptions, prefix *string, noProgress, verify bool) error {
+	term := ctx.Terminal(cc)
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	// Prepare progress bar
+	var reader io.Reader = file
+	if noProgress {
+		term.Printf(*prefix)
+		*prefix = ""
+	} else {
+		stat, _ := file.Stat()
+		bar := term.StartProgress(stat.Size(), *prefix)
+		reader = bar.NewProxyReader(file)
+		defer bar.Finish()
+	}
+
+	// Digest the file as it streams into the upload
+	hash := sha512.New()
+	reader = io.TeeReader(reader, hash)
+
+	name := filepath.Base(path)
+	v, err := c.PushPkg(cc, name, opts, reader)
+	if err != nil || !verify {
+		return err
+	}
+
+	sum := fmt.Sprintf("%x", hash.Sum(nil))
+	if exp := v.Digests.SHA512; !strings.EqualFold(exp, sum) {
+		return fmt.Errorf("%w: %s (local %s, server %q)", errPushChecksum, name, sum, exp)
+	}
+
+	return nil
+}
+
+// pushEntry is a file to upload with its own options
+type pushEntry struct {
+	Path    string `yaml:"path"`
+	Public  *bool  `yaml:"public"`
+	Account string `yaml:"account"`
+	Kind    string `yaml:"kind"`
+}
+
+// pushManifest is the YAML file listing files for "push --manifest"
+type pushManifest struct {
+	Files []pushEntry `yaml:"files"`
+}
+
+// Read and validate manifest. Relative paths are resolved from manifest location
+func readPushManifest(path string) ([]pushEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := pushManifest{}
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("Invalid manifest %q: %w", path, err)
+	} else if len(manifest.Files) == 0 {
+		return nil, fmt.Errorf("No files listed in manifest %q", path)
+	}
+
+	baseDir := filepath.Dir(path)
+	for i, e := range manifest.Files {
+		if e.Path == "" {
+			return nil, fmt.Errorf("Manifest entry #%d has no path", i+1)
+		} else if !filepath.IsAbs(e.Path) {
+			manifest.Files[i].Path = filepath.Join(baseDir, e.Path)
+		}
+	}
+
+	return manifest.Files, nil
+}
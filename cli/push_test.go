@@ -7,9 +7,12 @@ import (
 	"github.com/gemfury/cli/internal/testutil"
 	"github.com/gemfury/cli/pkg/terminal"
 
+	"crypto/sha512"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -88,8 +91,148 @@ func TestPushCommandSuccess(t *testing.T) {
 	}
 }
 
+func TestPushCommandVerify(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	packagePath := samplePackagePath()
+	content, err := os.ReadFile(packagePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Server responds with whichever digest is configured
+	digest := fmt.Sprintf("%x", sha512.Sum512(content))
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/uploads", func(w http.ResponseWriter, r *http.Request) {
+			io.Copy(io.Discard, r.Body)
+			fmt.Fprintf(w, `{"id":"ver_a1b2c3","digests":{"sha512":%q}}`, digest)
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.PushEndpoint = server.URL
+	flags.Endpoint = server.URL
+
+	// Matching checksum
+	err = runCommandNoErr(cc, []string{"push", "--verify", packagePath})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "Uploading sample.txt - done"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+
+	// Mismatching checksum
+	digest = strings.Repeat("0", 128)
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, auth)
+	flags = ctx.GlobalFlags(cc)
+	flags.PushEndpoint = server.URL
+	flags.Endpoint = server.URL
+
+	err = runCommand(cc, []string{"push", "--verify", packagePath})
+	if err == nil {
+		t.Fatalf("Expected checksum error")
+	}
+
+	exp = "Uploading sample.txt - CHECKSUM MISMATCH\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Output should be %q, got %q", exp, outStr)
+	}
+
+	if errStr := string(term.ErrBytes()); !strings.Contains(errStr, "checksum mismatch") {
+		t.Errorf("Expected checksum error output, got %q", errStr)
+	}
+}
+
+func TestPushCommandManifest(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+	uploads := []string{}
+
+	// Fire up test server that records per-file options
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/uploads", func(w http.ResponseWriter, r *http.Request) {
+			if err := r.ParseMultipartForm(1e6); err != nil {
+				t.Fatalf("ParseMultipartForm err: %s", err)
+			}
+			mf := r.MultipartForm
+			upload := fmt.Sprintf("as=%s public=%s kind=%s",
+				r.URL.Query().Get("as"), mf.Value["public"], mf.Value["kind"])
+			uploads = append(uploads, upload)
+			w.Write([]byte(pushResponse))
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.PushEndpoint = server.URL
+	flags.Endpoint = server.URL
+
+	manifest := fmt.Sprintf(`files:
+  - path: %s
+  - path: %s
+    public: true
+    account: org-name
+    kind: ruby
+  - path: missing.txt
+`, samplePackagePath(), samplePackagePath())
+
+	manifestPath := filepath.Join(t.TempDir(), "release.yaml")
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err := runCommand(cc, []string{"push", "--manifest", manifestPath})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected missing file error, got %v", err)
+	}
+
+	exp := []string{"as= public=[] kind=[]", "as=org-name public=[true] kind=[ruby]"}
+	if fmt.Sprint(uploads) != fmt.Sprint(exp) {
+		t.Errorf("Expected uploads %q, got %q", exp, uploads)
+	}
+
+	outStr := string(term.OutBytes())
+	if exp := "Uploading sample.txt to org-name - done\n"; !strings.Contains(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	} else if exp := "Uploading missing.txt - file not found\nFailed to upload 1 of 3 files\n"; !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to end with %q, got %q", exp, outStr)
+	}
+}
+
+func TestPushCommandQuietMissingFile(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServer(t, "POST", "/uploads", pushResponse, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.PushEndpoint = server.URL
+	flags.Endpoint = server.URL
+
+	missingPath := filepath.Join(t.TempDir(), "missing.txt")
+	args := []string{"push", "--quiet", samplePackagePath(), missingPath}
+	if err := runCommand(cc, args); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected missing file error, got %v", err)
+	}
+
+	exp := "Uploading sample.txt - done\nUploading missing.txt - file not found\nFailed to upload 1 of 2 files\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+}
+
 func TestPushCommandUnauthorized(t *testing.T) {
-	server := testutil.APIServer(t, "POST", "/uploads", "[]", 200)
+	server := testutil.APIServer(t, "POST", "/uploads", pushResponse, 200)
 	args := []string{"push", samplePackagePath()}
 	testCommandLoginPreCheck(t, args, server)
 	server.Close()
@@ -41,8 +41,14 @@ func NewRootCommand(cc context.Context) *cobra.Command {
 		NewCmdWhoAmI(),
 		NewCmdPackages(),
 		NewCmdVersions(),
+		NewCmdInfo(),
+		NewCmdSearch(),
+		NewCmdInstallHint(),
+		NewCmdSetup(),
+		NewCmdDownload(),
 		NewCmdSharingRoot(),
 		NewCmdAccounts(),
+		NewCmdAudit(),
 		NewCmdGitRoot(),
 		NewCmdLogout(),
 		NewCmdLogin(),
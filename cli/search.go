@@ -0,0 +1,196 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/spf13/cobra"
+
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// searchOptions are the flags for "search"
+type searchOptions struct {
+	Kind       string
+	UploadedBy string
+	Since      string
+	Private    bool
+	Sort       string
+	JSON       bool
+}
+
+// Sorting of search results, by name of "--sort" option
+var searchSorts = map[string]func(a, b *api.Version) int{
+	"name": func(a, b *api.Version) int {
+		return strings.Compare(strings.ToLower(searchPackageName(a)), strings.ToLower(searchPackageName(b)))
+	},
+	"kind": func(a, b *api.Version) int {
+		return strings.Compare(a.Kind(), b.Kind())
+	},
+	"uploaded": func(a, b *api.Version) int {
+		return b.CreatedAt.Compare(a.CreatedAt) // Newest first
+	},
+	"uploaded-by": func(a, b *api.Version) int {
+		return strings.Compare(a.DisplayCreatedBy(), b.DisplayCreatedBy())
+	},
+}
+
+// NewCmdSearch generates the Cobra command for "search"
+func NewCmdSearch() *cobra.Command {
+	opts := searchOptions{}
+
+	searchCmd := &cobra.Command{
+		Use:   "search [KIND:]QUERY",
+		Short: "Search package versions in this account",
+		Long: `Search package versions by package name, or part of it.
+
+Matching is done on the client, so every version in the account is
+scanned. Use KIND, or --kind, to only scan packages of that kind.
+The --since option accepts a duration, such as "30d", "2w", or "12h",
+or a date, such as "2024-01-31".`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a search query")
+			}
+
+			listOpts := listOptions{Sort: opts.Sort}
+			if err := listOpts.validate(listSortNames(searchSorts)); err != nil {
+				return err
+			}
+
+			var since time.Time
+			if opts.Since != "" {
+				t, err := parseSince(opts.Since, time.Now())
+				if err != nil {
+					return err
+				}
+				since = t
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			// Kind flag applies unless specified as "kind:query"
+			kind, query := splitPackageKind(args[0])
+			if kind == "" && opts.Kind != "" {
+				kind, _ = splitPackageKind(opts.Kind + ":")
+			}
+			query = strings.ToLower(query)
+
+			// Server-side filters, where the API supports them
+			filter := url.Values{}
+			if kind != "" {
+				filter.Set("kind", kind)
+			}
+
+			// Client-side filters, for everything else
+			match := func(v *api.Version) bool {
+				if kind != "" && v.Kind() != kind {
+					return false
+				} else if query != "" && !strings.Contains(strings.ToLower(searchPackageName(v)), query) {
+					return false
+				} else if !since.IsZero() && v.CreatedAt.Before(since) {
+					return false
+				} else if opts.Private && (v.Package == nil || !v.Package.IsPrivate) {
+					return false
+				} else if by := opts.UploadedBy; by != "" {
+					a := v.CreatedBy
+					return a != nil && (strings.EqualFold(a.Name, by) || strings.EqualFold(a.Username, by))
+				}
+				return true
+			}
+
+			versions := []*api.Version{}
+			err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+				resp, err := c.Versions(cc, filter, pageReq)
+				if err != nil {
+					return nil, err
+				}
+
+				for _, v := range resp.Versions {
+					if match(v) {
+						versions = append(versions, v)
+					}
+				}
+
+				return resp.Pagination, nil
+			})
+
+			sortListing(versions, listOpts, searchSorts)
+
+			if opts.JSON {
+				if jerr := termPrintJSON(term, versions); jerr != nil {
+					return jerr
+				}
+				return err
+			}
+
+			// Handle no results
+			if len(versions) == 0 {
+				term.Println("No matching versions found")
+				return err
+			}
+
+			// Print results
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "name\tversion\tkind\tprivacy\tuploaded_by\tuploaded_at\n")
+
+			for _, v := range versions {
+				privacy := "N/A"
+				if v.Package != nil {
+					privacy = v.Package.Privacy()
+				}
+				uploadedAt := timeStringWithAgo(v.CreatedAt)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", searchPackageName(v),
+					v.Version, v.Kind(), privacy, v.DisplayCreatedBy(), uploadedAt)
+			}
+
+			w.Flush()
+			return err
+		},
+	}
+
+	// Flags and options
+	flags := searchCmd.Flags()
+	flags.StringVar(&opts.Kind, "kind", "", "Filter to one kind of package")
+	flags.StringVar(&opts.UploadedBy, "uploaded-by", "", "Filter by uploader name or username")
+	flags.StringVar(&opts.Since, "since", "", "Filter to versions uploaded since duration or date")
+	flags.BoolVar(&opts.Private, "private", false, "Filter to private packages")
+	flags.StringVar(&opts.Sort, "sort", "name", "Sort by "+strings.Join(listSortNames(searchSorts), ", "))
+	flags.BoolVar(&opts.JSON, "json", false, "Output as JSON")
+
+	return searchCmd
+}
+
+func searchPackageName(v *api.Version) string {
+	if p := v.Package; p != nil {
+		return p.Name
+	}
+	return ""
+}
+
+// Time from a duration before now, like "30d", "2w", "12h", or a date
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+
+	days := map[byte]int{'d': 1, 'w': 7}
+	if n := len(s); n > 1 && days[s[n-1]] > 0 {
+		if count, err := strconv.Atoi(s[:n-1]); err == nil && count >= 0 {
+			return now.AddDate(0, 0, -count*days[s[n-1]]), nil
+		}
+	} else if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("Invalid --since %q, use a duration like 30d or a date like 2024-01-31", s)
+}
@@ -0,0 +1,162 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+var searchResponses = []string{`[{
+	"id": "ver_a1b2c3",
+	"version": "1.2.3",
+	"created_at": "2011-05-27T00:39:07+00:00",
+	"created_by": { "name": "User One", "username": "user1" },
+	"package": { "id": "pkg_a1", "name": "foo-client", "kind_key": "js", "private": true }
+}, {
+	"id": "ver_d4e5f6",
+	"version": "0.9.0",
+	"created_at": "2011-03-02T10:00:00+00:00",
+	"created_by": { "name": "User Two", "username": "user2" },
+	"package": { "id": "pkg_b2", "name": "bar", "kind_key": "js", "private": true }
+}]`, `[{
+	"id": "ver_z1y2x3",
+	"version": "2.0.0",
+	"created_at": "2011-01-27T00:44:00+00:00",
+	"created_by": { "name": "User Two", "username": "user2" },
+	"package": { "id": "pkg_c3", "name": "Foo", "kind_key": "ruby", "private": false }
+}]`}
+
+// ==== search ====
+
+func TestSearchCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	filter := ""
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/versions", func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			filter = "kind=" + q.Get("kind") + " name=" + q.Get("name")
+			testutil.APIPaginatedResponse(t, w, r, searchResponses, 200)
+		})
+	})
+	defer server.Close()
+
+	// Each case is args, expected server-side filters, and output
+	cases := []struct {
+		args   []string
+		filter string
+		out    string
+	}{{
+		[]string{"foo"}, "kind= name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"Foo 2.0.0 ruby public User Two 2011-01-26 16:44 " +
+			"foo-client 1.2.3 js private User One 2011-05-26 17:39",
+	}, {
+		[]string{"foo", "--sort", "uploaded"}, "kind= name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"foo-client 1.2.3 js private User One 2011-05-26 17:39 " +
+			"Foo 2.0.0 ruby public User Two 2011-01-26 16:44",
+	}, {
+		[]string{"O", "--private", "--kind", "npm"}, "kind=js name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"foo-client 1.2.3 js private User One 2011-05-26 17:39",
+	}, {
+		[]string{"gem:oo"}, "kind=ruby name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"Foo 2.0.0 ruby public User Two 2011-01-26 16:44",
+	}, {
+		[]string{"oo", "--kind", "ruby"}, "kind=ruby name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"Foo 2.0.0 ruby public User Two 2011-01-26 16:44",
+	}, {
+		[]string{"a", "--uploaded-by", "user2", "--since", "2011-03-01"}, "kind= name=",
+		"name version kind privacy uploaded_by uploaded_at " +
+			"bar 0.9.0 js private User Two 2011-03-02 02:00",
+	}, {
+		[]string{"foo", "--since", "30d"}, "kind= name=",
+		"No matching versions found",
+	}}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommandNoErr(cc, append([]string{"search"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if filter != c.filter {
+			t.Errorf("%v: Expected filter %q, got %q", c.args, c.filter, filter)
+		}
+
+		if outStr := compactString(term.OutBytes()); outStr != c.out {
+			t.Errorf("%v: Expected output %q, got %q", c.args, c.out, outStr)
+		}
+	}
+}
+
+func TestSearchCommandJSON(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServerPaginated(t, "GET", "/versions", searchResponses, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"search", "client", "--json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := []map[string]interface{}{}
+	if err := json.Unmarshal(term.OutBytes(), &out); err != nil {
+		t.Fatalf("Invalid JSON: %s", err)
+	} else if len(out) != 1 || out[0]["id"] != "ver_a1b2c3" {
+		t.Errorf("Unexpected results: %v", out)
+	}
+}
+
+func TestSearchCommandInvalid(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	// Each case is args and expected error
+	cases := []struct {
+		args []string
+		err  string
+	}{
+		{[]string{"foo", "--since", "soon"}, `Invalid --since "soon", use a duration like 30d or a date like 2024-01-31`},
+		{[]string{"foo", "--sort", "size"}, `Unknown sort "size". Available: kind, name, uploaded, uploaded-by`},
+		{[]string{}, "Please specify a search query"},
+	}
+
+	for _, c := range cases {
+		cc := cli.TestContext(terminal.NewForTest(), auth)
+		err := runCommand(cc, append([]string{"search"}, c.args...))
+		if err == nil || err.Error() != c.err {
+			t.Errorf("%v: Expected error %q, got %v", c.args, c.err, err)
+		}
+	}
+}
+
+func TestSearchCommandUnauthorized(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/versions", "[]", 200)
+	testCommandLoginPreCheck(t, []string{"search", "foo"}, server)
+	server.Close()
+}
+
+func TestSearchCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/versions", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"search", "foo"}, server)
+	server.Close()
+}
@@ -0,0 +1,624 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/spf13/cobra"
+
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Suffixes of files kept next to a config file for "setup --undo"
+const (
+	setupBackupSuffix  = ".fury-backup"  // Original content of the file
+	setupCreatedSuffix = ".fury-created" // Marker that setup created the file
+)
+
+// NewCmdSetup generates the Cobra command for "setup"
+func NewCmdSetup() *cobra.Command {
+	var undoFlag, forceFlag bool
+	var fileFlag, authFileFlag, tokenFlag string
+
+	setupCmd := &cobra.Command{
+		Use:   "setup " + strings.Join(setupToolNames(), "|"),
+		Short: "Configure a package manager for this account",
+		Long: `Configure a package manager to install from this account, using
+the repository URL and your credentials.
+
+Credentials are kept in files only readable by you, such as ~/.netrc.
+Machine-wide apt and yum setup needs a read-only deploy token, given
+with --token, rather than your login.
+
+Running it again only updates the file when something has changed.
+Existing settings that are not for Gemfury, such as another registry,
+are only replaced after confirmation.
+
+The original file is kept with a ` + setupBackupSuffix + ` suffix, and is
+restored with --undo. Files created by setup are removed with --undo.
+Entries in ~/.netrc, which is shared by other tools, are removed instead.`,
+		ValidArgs: setupToolNames(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a package manager")
+			}
+
+			tool, err := findSetupTool(args[0])
+			if err != nil {
+				return err
+			} else if authFileFlag != "" && tool.auth == nil && tool.netrcHost == "" {
+				return fmt.Errorf("%s has no separate credentials file", args[0])
+			}
+
+			files, err := tool.files(fileFlag, authFileFlag)
+			if err != nil {
+				return err
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+
+			if undoFlag {
+				for _, f := range files {
+					msg, err := f.undo()
+					if err != nil {
+						return err
+					}
+					term.Println(msg)
+				}
+				return nil
+			}
+
+			if tool.system && tokenFlag == "" {
+				return fmt.Errorf("Machine-wide %s setup needs a read-only deploy token, use --token", args[0])
+			}
+
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			account, err := currentAccountName(cc, c)
+			if err != nil {
+				return err
+			}
+
+			repo := setupRepo{account, c.Token}
+			if tokenFlag != "" {
+				repo.token = tokenFlag
+			}
+
+			// Prepare all files before writing any of them
+			changed, replaced := []*setupPending{}, []string{}
+			for _, f := range files {
+				if err := f.prepare(repo); err != nil {
+					return err
+				} else if f.changed() {
+					changed = append(changed, f)
+					for _, line := range f.replaced {
+						replaced = append(replaced, fmt.Sprintf("  %s: %s", f.path, line))
+					}
+				}
+			}
+
+			if len(changed) == 0 {
+				term.Printf("%s is already set up for %q\n", files[0].path, account)
+				return nil
+			}
+
+			if len(replaced) > 0 && !forceFlag {
+				term.Printf("Existing settings to replace:\n%s\n", strings.Join(replaced, "\n"))
+				confirm := fmt.Sprintf("Replace %d existing settings? [y/N]", len(replaced))
+				if ok, err := terminal.PromptConfirm(term, confirm); !ok {
+					return err
+				}
+			}
+
+			for _, f := range changed {
+				if err := f.write(); err != nil {
+					return err
+				}
+				term.Printf("Updated %s for %q\n", f.path, account)
+			}
+
+			return nil
+		},
+	}
+
+	// Flags and options
+	setupCmd.Flags().BoolVar(&undoFlag, "undo", false, "Restore the file from before setup")
+	setupCmd.Flags().StringVar(&fileFlag, "file", "", "Config file to update instead of default")
+	setupCmd.Flags().StringVar(&authFileFlag, "auth-file", "", "Credentials file to update instead of default")
+	setupCmd.Flags().StringVar(&tokenFlag, "token", "", "Read-only deploy token to use instead of your login")
+	setupCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Replace existing settings without confirmation")
+
+	return setupCmd
+}
+
+// setupRepo is the account and credentials written by setup
+type setupRepo struct {
+	account string
+	token   string
+}
+
+// setupFile is a config file, and how to add the repo to its content.
+// Besides the content, update returns existing settings it replaces.
+type setupFile struct {
+	path   func() (string, error)
+	mode   os.FileMode
+	update func(content string, r setupRepo) (string, []string, error)
+}
+
+// setupTool is a package manager and its config files
+type setupTool struct {
+	setupFile
+	auth      *setupFile // Credentials, kept apart when config is readable by others
+	netrcHost string     // Credentials as an entry of ~/.netrc, shared with other tools
+	system    bool       // Machine-wide, so a deploy token is used instead of login
+}
+
+var setupTools = map[string]setupTool{
+	"npm": {setupFile: setupFile{homePath(".npmrc"), 0600, func(content string, r setupRepo) (string, []string, error) {
+		repo := furyRepoURL("npm", r.account, "", "")
+		out, replaced := mergeKeyValues(content, "=", "", [][2]string{
+			{"registry", repo},
+			{strings.TrimPrefix(repo, "https:") + ":_authToken", r.token},
+		})
+		return out, replaced, nil
+	}}},
+	"pip": {setupFile: setupFile{pipConfigPath, 0600, func(content string, r setupRepo) (string, []string, error) {
+		repo := furyRepoURL("pypi", r.account, "", "")
+		out, replaced := mergeKeyValues(content, " = ", "global", [][2]string{
+			{"index-url", repo},
+		})
+		return out, replaced, nil
+	}}, netrcHost: "pypi.fury.io"},
+	"bundler": {setupFile: setupFile{homePath(".bundle", "config"), 0600, func(content string, r setupRepo) (string, []string, error) {
+		if content == "" {
+			content = "---\n"
+		}
+		out, replaced := mergeKeyValues(content, ": ", "", [][2]string{
+			{"BUNDLE_GEM__FURY__IO", fmt.Sprintf("%q", r.token)},
+		})
+		return out, replaced, nil
+	}}},
+	"go": {setupFile: setupFile{goEnvPath, 0644, func(content string, r setupRepo) (string, []string, error) {
+		repo := furyRepoURL("go", r.account, "", "")
+		out, replaced := mergeKeyValues(content, "=", "", [][2]string{
+			{"GOPROXY", repo + ",https://proxy.golang.org,direct"},
+		})
+		return out, replaced, nil
+	}}, netrcHost: "go.fury.io"},
+	"maven": {setupFile: setupFile{homePath(".m2", "settings.xml"), 0600, func(content string, r setupRepo) (string, []string, error) {
+		if content == "" {
+			content = "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<settings>\n</settings>\n"
+		}
+		repo := furyRepoURL("maven", r.account, "", "")
+		out, err := mergeXMLBlocks(content, "settings", [][2]string{{"servers",
+			"<server>\n  <id>fury</id>\n" +
+				"  <username>" + r.token + "</username>\n" +
+				"  <password>NOPASS</password>\n</server>\n",
+		}, {"profiles",
+			"<profile>\n  <id>fury</id>\n  <repositories>\n    <repository>\n" +
+				"      <id>fury</id>\n      <url>" + repo + "</url>\n" +
+				"    </repository>\n  </repositories>\n</profile>\n",
+		}, {"activeProfiles",
+			"<activeProfile>fury</activeProfile>\n",
+		}})
+		return out, nil, err
+	}}},
+	"nuget": {setupFile: setupFile{nugetConfigPath, 0600, func(content string, r setupRepo) (string, []string, error) {
+		if content == "" {
+			content = "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<configuration>\n</configuration>\n"
+		}
+		repo := furyRepoURL("nuget", r.account, "", "")
+		out, err := mergeXMLBlocks(content, "configuration", [][2]string{{"packageSources",
+			"<add key=\"fury\" value=\"" + repo + "\" />\n",
+		}, {"packageSourceCredentials",
+			"<fury>\n  <add key=\"Username\" value=\"" + r.token + "\" />\n" +
+				"  <add key=\"ClearTextPassword\" value=\"NOPASS\" />\n</fury>\n",
+		}})
+		return out, nil, err
+	}}},
+	"apt": {setupFile: setupFile{fixedPath("/etc/apt/sources.list.d/fury.list"), 0644, func(_ string, r setupRepo) (string, []string, error) {
+		repo := furyRepoURL("apt", r.account, "", "")
+		return "deb [trusted=yes] " + repo + " /\n", nil, nil
+	}}, auth: &setupFile{fixedPath("/etc/apt/auth.conf.d/fury.conf"), 0600, func(content string, r setupRepo) (string, []string, error) {
+		host := strings.TrimPrefix(furyRepoURL("apt", r.account, "", ""), "https://")
+		return mergeNetrc(content, host, r.token), nil, nil
+	}}, system: true},
+	"yum": {setupFile: setupFile{fixedPath("/etc/yum.repos.d/fury.repo"), 0600, func(_ string, r setupRepo) (string, []string, error) {
+		repo := furyRepoURL("yum", r.account, "", "")
+		return "[fury]\nname=Gemfury Private Repo\nbaseurl=" + repo + "\n" +
+			"username=" + r.token + "\npassword=NOPASS\nenabled=1\ngpgcheck=0\n", nil, nil
+	}}, system: true},
+}
+
+// Credentials for host in ~/.netrc, used by Go, pip, and others
+func netrcSetupFile(host string) setupFile {
+	return setupFile{netrcPath, 0600, func(content string, r setupRepo) (string, []string, error) {
+		return mergeNetrc(content, host, r.token), nil, nil
+	}}
+}
+
+// setupPending is a file with content prepared, but not yet written
+type setupPending struct {
+	file      setupFile
+	path      string
+	netrcHost string // Shared file, so only this entry is undone
+	old       []byte
+	exists    bool
+	updated   string
+	replaced  []string
+}
+
+// Config file and credentials file, unless paths are overridden
+func (t setupTool) files(path, authPath string) ([]*setupPending, error) {
+	files := []*setupPending{{file: t.setupFile, path: path}}
+	if t.auth != nil {
+		files = append(files, &setupPending{file: *t.auth, path: authPath})
+	} else if t.netrcHost != "" {
+		files = append(files, &setupPending{file: netrcSetupFile(t.netrcHost), path: authPath, netrcHost: t.netrcHost})
+	}
+
+	for _, f := range files {
+		if f.path != "" {
+			continue
+		}
+		p, err := f.file.path()
+		if err != nil {
+			return nil, err
+		}
+		f.path = p
+	}
+
+	return files, nil
+}
+
+func (f *setupPending) prepare(r setupRepo) error {
+	old, err := os.ReadFile(f.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	f.old, f.exists = old, err == nil
+
+	f.updated, f.replaced, err = f.file.update(string(old), r)
+	if err != nil {
+		return fmt.Errorf("Unable to update %s: %w", f.path, err)
+	}
+	return nil
+}
+
+func (f *setupPending) changed() bool {
+	return !f.exists || f.updated != string(f.old)
+}
+
+// Write after backup, restricting permissions of existing file first.
+// Shared files aren't backed up, as other tools may change them later.
+func (f *setupPending) write() error {
+	if f.netrcHost == "" {
+		if err := backupSetupFile(f.path, f.old, f.exists); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0755); err != nil {
+		return err
+	}
+
+	if f.exists {
+		if info, err := os.Stat(f.path); err != nil {
+			return err
+		} else if perm := info.Mode().Perm(); perm&^f.file.mode != 0 {
+			if err := os.Chmod(f.path, perm&f.file.mode); err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.WriteFile(f.path, []byte(f.updated), f.file.mode)
+}
+
+// Restore or remove the file, or only remove the entry from a shared file
+func (f *setupPending) undo() (string, error) {
+	if f.netrcHost == "" {
+		return undoSetupFile(f.path)
+	}
+
+	old, err := os.ReadFile(f.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "Nothing to undo for " + f.path, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	updated := removeNetrc(string(old), f.netrcHost)
+	if updated == strings.TrimRight(string(old), "\n")+"\n" {
+		return "Nothing to undo for " + f.path, nil
+	} else if updated == "" {
+		return "Removed " + f.path, os.Remove(f.path)
+	}
+
+	msg := fmt.Sprintf("Removed %s from %s", f.netrcHost, f.path)
+	return msg, os.WriteFile(f.path, []byte(updated), f.file.mode)
+}
+
+func setupToolNames() []string {
+	names := make([]string, 0, len(setupTools))
+	for name := range setupTools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func findSetupTool(name string) (setupTool, error) {
+	if tool, ok := setupTools[strings.ToLower(name)]; ok {
+		return tool, nil
+	}
+
+	names := setupToolNames()
+	if matches := closestMatches(name, names); len(matches) > 0 {
+		return setupTool{}, fmt.Errorf("Unknown package manager %q. Did you mean %q?", name, matches[0])
+	}
+
+	return setupTool{}, fmt.Errorf("Unknown package manager %q. Available: %s", name, strings.Join(names, ", "))
+}
+
+// Keep the original file, or mark it as created, unless already done
+func backupSetupFile(path string, old []byte, exists bool) error {
+	backup, created := path+setupBackupSuffix, path+setupCreatedSuffix
+	for _, p := range []string{backup, created} {
+		if _, err := os.Stat(p); err == nil {
+			return nil
+		}
+	}
+
+	if !exists {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(created, nil, 0600)
+	}
+
+	return os.WriteFile(backup, old, 0600)
+}
+
+// Restore the original file, or remove it when created by setup
+func undoSetupFile(path string) (string, error) {
+	backup, created := path+setupBackupSuffix, path+setupCreatedSuffix
+
+	if _, err := os.Stat(backup); err == nil {
+		return "Restored " + path, os.Rename(backup, path)
+	}
+
+	if _, err := os.Stat(created); err == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		return "Removed " + path, os.Remove(created)
+	}
+
+	return "Nothing to undo for " + path, nil
+}
+
+// Set "key<sep>value" lines, within "[section]" when not empty.
+// Existing keys are replaced in place, and missing keys appended.
+// Replaced lines that aren't for Gemfury, like another registry, are returned.
+func mergeKeyValues(content, sep, section string, kv [][2]string) (string, []string) {
+	lines := []string{}
+	if trimmed := strings.TrimRight(content, "\n"); trimmed != "" {
+		lines = strings.Split(trimmed, "\n")
+	}
+
+	values := map[string]string{}
+	for _, p := range kv {
+		values[p[0]] = p[1]
+	}
+
+	out := make([]string, 0, len(lines)+len(kv)+1)
+	replaced := []string{}
+	done := map[string]bool{}
+	inSection, sectionEnd := section == "", -1
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if section != "" && strings.HasPrefix(trimmed, "[") {
+			if inSection {
+				sectionEnd = len(out)
+			}
+			inSection = trimmed == "["+section+"]"
+		}
+
+		if inSection {
+			key, old, found := strings.Cut(trimmed, strings.TrimSpace(sep))
+			if value, ok := values[strings.TrimSpace(key)]; found && ok {
+				key = strings.TrimSpace(key)
+				if strings.TrimSpace(old) != value && !strings.Contains(strings.ToLower(trimmed), "fury") {
+					replaced = append(replaced, trimmed)
+				}
+				if !done[key] {
+					out = append(out, key+sep+value)
+					done[key] = true
+				}
+				continue
+			}
+		}
+
+		out = append(out, line)
+	}
+
+	missing := []string{}
+	for _, p := range kv {
+		if !done[p[0]] {
+			missing = append(missing, p[0]+sep+p[1])
+		}
+	}
+
+	if len(missing) > 0 {
+		if inSection && sectionEnd < 0 {
+			sectionEnd = len(out) // Section runs to end of file
+		}
+
+		if sectionEnd >= 0 {
+			// Insert before blank lines that separate sections
+			for sectionEnd > 0 && strings.TrimSpace(out[sectionEnd-1]) == "" {
+				sectionEnd--
+			}
+			missing = append(missing, out[sectionEnd:]...)
+			out = append(out[:sectionEnd], missing...)
+		} else {
+			if len(out) > 0 {
+				out = append(out, "")
+			}
+			out = append(out, "["+section+"]")
+			out = append(out, missing...)
+		}
+	}
+
+	return strings.Join(out, "\n") + "\n", replaced
+}
+
+// Set the netrc entry of host, replacing any other entry of it
+func mergeNetrc(content, host, token string) string {
+	return replaceNetrc(content, host, "machine "+host+" login "+token+" password NOPASS")
+}
+
+// Remove the netrc entry of host, leaving other entries alone
+func removeNetrc(content, host string) string {
+	return replaceNetrc(content, host, "")
+}
+
+// Replace entries of host with entry, or remove them when entry is empty
+func replaceNetrc(content, host, entry string) string {
+	lines := []string{}
+	if trimmed := strings.TrimRight(content, "\n"); trimmed != "" {
+		lines = strings.Split(trimmed, "\n")
+	}
+
+	out := make([]string, 0, len(lines)+1)
+	inEntry, done := false, false
+	for _, line := range lines {
+		if fields := strings.Fields(line); len(fields) > 0 && (fields[0] == "machine" || fields[0] == "default") {
+			inEntry = len(fields) > 1 && fields[0] == "machine" && fields[1] == host
+			if inEntry && !done && entry != "" {
+				out = append(out, entry)
+				done = true
+			}
+		}
+
+		if !inEntry {
+			out = append(out, line)
+		}
+	}
+
+	if !done && entry != "" {
+		out = append(out, entry)
+	} else if len(out) == 0 {
+		return ""
+	}
+
+	return strings.Join(out, "\n") + "\n"
+}
+
+// Replace blocks of XML elements marked as managed by setup, or add
+// them to their parent elements, which are created within root if missing
+func mergeXMLBlocks(content, root string, blocks [][2]string) (string, error) {
+	for _, b := range blocks {
+		parent, block := b[0], b[1]
+		begin, end := "<!-- fury:"+parent+" -->", "<!-- /fury:"+parent+" -->"
+
+		managed := "    " + begin + "\n"
+		for _, line := range strings.Split(strings.TrimRight(block, "\n"), "\n") {
+			managed += "    " + line + "\n"
+		}
+		managed += "    " + end
+
+		if i := strings.Index(content, begin); i >= 0 {
+			if j := strings.Index(content[i:], end); j >= 0 {
+				start := strings.LastIndex(content[:i], "\n") + 1
+				content = content[:start] + managed + content[i+j+len(end):]
+				continue
+			}
+		}
+
+		if i := strings.LastIndex(content, "</"+parent+">"); i >= 0 {
+			start := strings.LastIndex(content[:i], "\n") + 1
+			content = content[:start] + managed + "\n" + content[start:]
+		} else if i := strings.LastIndex(content, "</"+root+">"); i >= 0 {
+			start := strings.LastIndex(content[:i], "\n") + 1
+			element := "  <" + parent + ">\n" + managed + "\n  </" + parent + ">\n"
+			content = content[:start] + element + content[start:]
+		} else {
+			return "", fmt.Errorf("Missing <%s> element", root)
+		}
+	}
+
+	return content, nil
+}
+
+// Path within home directory
+func homePath(elem ...string) func() (string, error) {
+	return func() (string, error) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(append([]string{home}, elem...)...), nil
+	}
+}
+
+func fixedPath(path string) func() (string, error) {
+	return func() (string, error) {
+		return path, nil
+	}
+}
+
+// Same file as Go and curl, unless overridden with $NETRC
+func netrcPath() (string, error) {
+	if path := os.Getenv("NETRC"); path != "" {
+		return path, nil
+	} else if runtime.GOOS == "windows" {
+		return homePath("_netrc")()
+	}
+	return homePath(".netrc")()
+}
+
+func pipConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	} else if runtime.GOOS == "windows" {
+		return filepath.Join(dir, "pip", "pip.ini"), nil
+	}
+	return filepath.Join(dir, "pip", "pip.conf"), nil
+}
+
+// Same file as "go env -w"
+func goEnvPath() (string, error) {
+	if path := os.Getenv("GOENV"); path != "" && path != "off" {
+		return path, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go", "env"), nil
+}
+
+func nugetConfigPath() (string, error) {
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "NuGet", "NuGet.Config"), nil
+	}
+	return homePath(".nuget", "NuGet", "NuGet.Config")()
+}
@@ -0,0 +1,326 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// ==== setup ====
+
+func TestSetupCommandSuccess(t *testing.T) {
+	// Each case is tool, extra args, original content (none if empty), expected
+	// content, and original and expected content of credentials file, if any
+	cases := []struct {
+		tool     string
+		args     []string
+		orig     string
+		exp      string
+		authOrig string
+		authExp  string
+	}{{
+		"npm", nil, "",
+		"registry=https://npm.fury.io/acme/\n//npm.fury.io/acme/:_authToken=abc123\n", "", "",
+	}, {
+		"npm", []string{"--force"}, "save-exact=true\nregistry=https://registry.npmjs.org/\n",
+		"save-exact=true\nregistry=https://npm.fury.io/acme/\n//npm.fury.io/acme/:_authToken=abc123\n", "", "",
+	}, {
+		"pip", nil, "[global]\ntimeout = 60\n\n[install]\nno-cache-dir = true\n",
+		"[global]\ntimeout = 60\nindex-url = https://pypi.fury.io/acme/\n\n[install]\nno-cache-dir = true\n",
+		"", "machine pypi.fury.io login abc123 password NOPASS\n",
+	}, {
+		"pip", nil, "[install]\nno-cache-dir = true\n",
+		"[install]\nno-cache-dir = true\n\n[global]\nindex-url = https://pypi.fury.io/acme/\n",
+		"", "machine pypi.fury.io login abc123 password NOPASS\n",
+	}, {
+		"bundler", nil, "",
+		"---\nBUNDLE_GEM__FURY__IO: \"abc123\"\n", "", "",
+	}, {
+		"go", []string{"--force"}, "GOPRIVATE=example.com\nGOPROXY=direct\n",
+		"GOPRIVATE=example.com\nGOPROXY=https://go.fury.io/acme/,https://proxy.golang.org,direct\n",
+		"machine example.com login me password secret\n",
+		"machine example.com login me password secret\nmachine go.fury.io login abc123 password NOPASS\n",
+	}, {
+		"apt", []string{"--token", "deploy1"}, "",
+		"deb [trusted=yes] https://apt.fury.io/acme/ /\n",
+		"", "machine apt.fury.io/acme/ login deploy1 password NOPASS\n",
+	}, {
+		"yum", []string{"--token", "deploy1"}, "",
+		"[fury]\nname=Gemfury Private Repo\nbaseurl=https://yum.fury.io/acme/\n" +
+			"username=deploy1\npassword=NOPASS\nenabled=1\ngpgcheck=0\n", "", "",
+	}, {
+		"nuget", nil, "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<configuration>\n  <packageSources>\n" +
+			"    <add key=\"nuget.org\" value=\"https://api.nuget.org/v3/index.json\" />\n" +
+			"  </packageSources>\n</configuration>\n",
+		"<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<configuration>\n  <packageSources>\n" +
+			"    <add key=\"nuget.org\" value=\"https://api.nuget.org/v3/index.json\" />\n" +
+			"    <!-- fury:packageSources -->\n" +
+			"    <add key=\"fury\" value=\"https://nuget.fury.io/acme/\" />\n" +
+			"    <!-- /fury:packageSources -->\n" +
+			"  </packageSources>\n" +
+			"  <packageSourceCredentials>\n" +
+			"    <!-- fury:packageSourceCredentials -->\n" +
+			"    <fury>\n      <add key=\"Username\" value=\"abc123\" />\n" +
+			"      <add key=\"ClearTextPassword\" value=\"NOPASS\" />\n    </fury>\n" +
+			"    <!-- /fury:packageSourceCredentials -->\n" +
+			"  </packageSourceCredentials>\n</configuration>\n", "", "",
+	}}
+
+	for _, c := range cases {
+		dir := t.TempDir()
+		path, authPath := filepath.Join(dir, "config"), filepath.Join(dir, "auth")
+		if c.orig != "" {
+			os.WriteFile(path, []byte(c.orig), 0600)
+		}
+		if c.authOrig != "" {
+			os.WriteFile(authPath, []byte(c.authOrig), 0600)
+		}
+
+		args := []string{"setup", c.tool, "-a", "acme", "--file", path}
+		if c.authExp != "" {
+			args = append(args, "--auth-file", authPath)
+		}
+		args = append(args, c.args...)
+
+		if err := runSetupCommand(args); err != nil {
+			t.Fatalf("%s: %s", c.tool, err)
+		}
+
+		if out, _ := os.ReadFile(path); string(out) != c.exp {
+			t.Errorf("%s: Expected content %q, got %q", c.tool, c.exp, out)
+		}
+
+		if c.authExp != "" {
+			if out, _ := os.ReadFile(authPath); string(out) != c.authExp {
+				t.Errorf("%s: Expected credentials %q, got %q", c.tool, c.authExp, out)
+			} else if info, _ := os.Stat(authPath); runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+				t.Errorf("%s: Expected credentials mode 0600, got %s", c.tool, info.Mode())
+			}
+		}
+
+		// Running again changes nothing
+		term := terminal.NewForTest()
+		if err := runCommandNoErr(cli.TestContext(term, setupTestAuther()), args); err != nil {
+			t.Fatalf("%s: %s", c.tool, err)
+		} else if exp := path + " is already set up for \"acme\"\n"; string(term.OutBytes()) != exp {
+			t.Errorf("%s: Expected output %q, got %q", c.tool, exp, term.OutBytes())
+		} else if out, _ := os.ReadFile(path); string(out) != c.exp {
+			t.Errorf("%s: Expected unchanged content %q, got %q", c.tool, c.exp, out)
+		}
+
+		// Undo restores the originals, or removes the files
+		if err := runSetupCommand(append(args, "--undo")); err != nil {
+			t.Fatalf("%s: %s", c.tool, err)
+		}
+
+		out, err := os.ReadFile(path)
+		if c.orig == "" && !os.IsNotExist(err) {
+			t.Errorf("%s: Expected file to be removed, got %q", c.tool, out)
+		} else if c.orig != "" && string(out) != c.orig {
+			t.Errorf("%s: Expected original content %q, got %q", c.tool, c.orig, out)
+		}
+
+		out, err = os.ReadFile(authPath)
+		if c.authOrig == "" && !os.IsNotExist(err) {
+			t.Errorf("%s: Expected credentials to be removed, got %q", c.tool, out)
+		} else if c.authOrig != "" && string(out) != c.authOrig {
+			t.Errorf("%s: Expected original credentials %q, got %q", c.tool, c.authOrig, out)
+		}
+
+		if matches, _ := filepath.Glob(filepath.Join(dir, "*.fury-*")); len(matches) > 0 {
+			t.Errorf("%s: Expected no leftover files, got %q", c.tool, matches)
+		}
+	}
+}
+
+func TestSetupCommandReplaceSettings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".npmrc")
+	orig := "registry=https://registry.npmjs.org/\n"
+	os.WriteFile(path, []byte(orig), 0644)
+
+	// Declined replacement leaves file alone
+	term := terminal.NewForTest()
+	term.SetPromptResponses(map[string]string{"Replace 1 existing settings? [y/N]": "ABORT"})
+	args := []string{"setup", "npm", "-a", "acme", "--file", path}
+	if err := runCommandNoErr(cli.TestContext(term, setupTestAuther()), args); err != nil {
+		t.Fatal(err)
+	} else if exp := "Existing settings to replace:\n  " + path + ": " + strings.TrimSpace(orig) + "\n"; string(term.OutBytes()) != exp {
+		t.Errorf("Expected output %q, got %q", exp, term.OutBytes())
+	} else if out, _ := os.ReadFile(path); string(out) != orig {
+		t.Errorf("Expected unchanged content %q, got %q", orig, out)
+	}
+
+	// Confirmed replacement also restricts permissions of the file
+	term = terminal.NewForTest()
+	term.SetPromptResponses(map[string]string{"Replace 1 existing settings? [y/N]": "y"})
+	if err := runCommandNoErr(cli.TestContext(term, setupTestAuther()), args); err != nil {
+		t.Fatal(err)
+	} else if out, _ := os.ReadFile(path); !strings.HasPrefix(string(out), "registry=https://npm.fury.io/acme/\n") {
+		t.Errorf("Expected replaced registry, got %q", out)
+	} else if info, _ := os.Stat(path); runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("Expected mode 0600, got %s", info.Mode())
+	}
+}
+
+func TestSetupCommandSharedNetrc(t *testing.T) {
+	dir := t.TempDir()
+	netrc := filepath.Join(dir, "netrc")
+	os.WriteFile(netrc, []byte("machine example.com login me password secret\n"+
+		"machine go.fury.io\n  login old\n  password NOPASS\n"), 0600)
+
+	pipArgs := []string{"setup", "pip", "-a", "acme", "--file", filepath.Join(dir, "pip.conf"), "--auth-file", netrc}
+	goArgs := []string{"setup", "go", "-a", "acme", "--file", filepath.Join(dir, "env"), "--auth-file", netrc}
+	for _, args := range [][]string{pipArgs, goArgs} {
+		if err := runSetupCommand(args); err != nil {
+			t.Fatalf("%v: %s", args, err)
+		}
+	}
+
+	exp := "machine example.com login me password secret\n" +
+		"machine go.fury.io login abc123 password NOPASS\n" +
+		"machine pypi.fury.io login abc123 password NOPASS\n"
+	if out, _ := os.ReadFile(netrc); string(out) != exp {
+		t.Errorf("Expected credentials %q, got %q", exp, out)
+	}
+
+	// Each case is undo args, expected output for credentials, and remaining credentials
+	cases := []struct {
+		args []string
+		out  string
+		exp  string
+	}{{
+		pipArgs, "Removed pypi.fury.io from " + netrc,
+		"machine example.com login me password secret\nmachine go.fury.io login abc123 password NOPASS\n",
+	}, {
+		pipArgs, "Nothing to undo for " + netrc,
+		"machine example.com login me password secret\nmachine go.fury.io login abc123 password NOPASS\n",
+	}, {
+		goArgs, "Removed go.fury.io from " + netrc,
+		"machine example.com login me password secret\n",
+	}}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		err := runCommandNoErr(cli.TestContext(term, setupTestAuther()), append(c.args, "--undo"))
+		if err != nil {
+			t.Fatalf("%s: %s", c.args[1], err)
+		} else if outStr := string(term.OutBytes()); !strings.HasSuffix(outStr, c.out+"\n") {
+			t.Errorf("%s: Expected output %q, got %q", c.args[1], c.out, outStr)
+		} else if out, _ := os.ReadFile(netrc); string(out) != c.exp {
+			t.Errorf("%s: Expected credentials %q, got %q", c.args[1], c.exp, out)
+		}
+	}
+
+	if matches, _ := filepath.Glob(filepath.Join(dir, "*")); len(matches) != 1 {
+		t.Errorf("Expected only credentials to be left, got %q", matches)
+	}
+}
+
+func TestSetupCommandMavenUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "settings.xml")
+
+	args := []string{"setup", "maven", "-a", "acme", "--file", path}
+	if err := runSetupCommand(args); err != nil {
+		t.Fatal(err)
+	}
+
+	// Switching accounts replaces the managed blocks
+	args = []string{"setup", "maven", "-a", "other", "--file", path}
+	if err := runSetupCommand(args); err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<settings>\n" +
+		"  <servers>\n    <!-- fury:servers -->\n    <server>\n      <id>fury</id>\n" +
+		"      <username>abc123</username>\n      <password>NOPASS</password>\n" +
+		"    </server>\n    <!-- /fury:servers -->\n  </servers>\n" +
+		"  <profiles>\n    <!-- fury:profiles -->\n    <profile>\n      <id>fury</id>\n" +
+		"      <repositories>\n        <repository>\n          <id>fury</id>\n" +
+		"          <url>https://maven.fury.io/other/</url>\n        </repository>\n" +
+		"      </repositories>\n    </profile>\n    <!-- /fury:profiles -->\n  </profiles>\n" +
+		"  <activeProfiles>\n    <!-- fury:activeProfiles -->\n" +
+		"    <activeProfile>fury</activeProfile>\n    <!-- /fury:activeProfiles -->\n" +
+		"  </activeProfiles>\n</settings>\n"
+	if out, _ := os.ReadFile(path); string(out) != exp {
+		t.Errorf("Expected content %q, got %q", exp, out)
+	}
+
+	// Undo removes the file created by the first setup
+	if err := runSetupCommand(append(args, "--undo")); err != nil {
+		t.Fatal(err)
+	} else if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("Expected file to be removed, got %v", err)
+	}
+
+	// Undo without setup is a no-op
+	term := terminal.NewForTest()
+	err := runCommandNoErr(cli.TestContext(term, setupTestAuther()), append(args, "--undo"))
+	if exp := "Nothing to undo for " + path + "\n"; err != nil || string(term.OutBytes()) != exp {
+		t.Errorf("Expected output %q, got %q (%v)", exp, term.OutBytes(), err)
+	}
+}
+
+func TestSetupCommandInvalid(t *testing.T) {
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, setupTestAuther())
+
+	// Unknown package manager
+	exp := `Unknown package manager "npn". Did you mean "npm"?`
+	if err := runCommand(cc, []string{"setup", "npn"}); err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	}
+
+	// Machine-wide setup never uses the login token
+	exp = "Machine-wide apt setup needs a read-only deploy token, use --token"
+	if err := runCommand(cc, []string{"setup", "apt", "-a", "acme"}); err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	}
+
+	// Credentials file only for tools that keep one
+	exp = "npm has no separate credentials file"
+	if err := runCommand(cc, []string{"setup", "npm", "--auth-file", "auth"}); err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	}
+
+	// XML file without expected root element
+	path := filepath.Join(t.TempDir(), "settings.xml")
+	os.WriteFile(path, []byte("<project></project>\n"), 0600)
+
+	term = terminal.NewForTest()
+	cc = cli.TestContext(term, setupTestAuther())
+	err := runCommand(cc, []string{"setup", "maven", "-a", "acme", "--file", path})
+	if exp := "Unable to update " + path + ": Missing <settings> element"; err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	} else if matches, _ := filepath.Glob(path + ".fury-*"); len(matches) > 0 {
+		t.Errorf("Expected no backup on failure, got %q", matches)
+	}
+}
+
+func TestSetupCommandUnauthorized(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".npmrc")
+	server := testutil.APIServer(t, "GET", "/users/me", `{"username": "acme"}`, 200)
+	testCommandLoginPreCheck(t, []string{"setup", "npm", "--file", path}, server)
+	server.Close()
+}
+
+func TestSetupCommandForbidden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".npmrc")
+	server := testutil.APIServer(t, "GET", "/users/me", "", 403)
+	testCommandForbiddenResponse(t, []string{"setup", "npm", "--file", path}, server)
+	server.Close()
+}
+
+func setupTestAuther() terminal.Auther {
+	return terminal.TestAuther("user", "abc123", nil)
+}
+
+func runSetupCommand(args []string) error {
+	cc := cli.TestContext(terminal.NewForTest(), setupTestAuther())
+	return runCommandNoErr(cc, args)
+}
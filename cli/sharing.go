@@ -6,26 +6,61 @@ import (
 	"github.com/hashicorp/go-multierror"
 	"github.com/spf13/cobra"
 
+	"encoding/json"
 	"fmt"
 	"log"
+	"strings"
 	"text/tabwriter"
+	"time"
 )
 
 // Root for sharing/collaboration subcommands
 func NewCmdSharingRoot() *cobra.Command {
+	opts := listOptions{}
+
 	gitCmd := &cobra.Command{
 		Use:   "sharing",
 		Short: "Collaboration commands",
-		RunE:  listMembers,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listMembers(cmd, args, opts)
+		},
 	}
 
+	// Flags and options
+	addListFlags(gitCmd, &opts, listSortNames(memberSorts), false)
+	gitCmd.Flags().BoolVar(&opts.JSONLines, "jsonl", false, "Output as JSON Lines")
+
 	gitCmd.AddCommand(NewCmdSharingAdd())
+	gitCmd.AddCommand(NewCmdSharingUpdate())
 	gitCmd.AddCommand(NewCmdSharingRemove())
+	gitCmd.AddCommand(NewCmdSharingInvites())
+	gitCmd.AddCommand(NewCmdSharingSync())
 
 	return gitCmd
 }
 
-func listMembers(cmd *cobra.Command, args []string) error {
+// Sorting of "sharing", by name of "--sort" option
+var memberSorts = map[string]func(a, b *api.Member) int{
+	"name":  func(a, b *api.Member) int { return strings.Compare(a.Name, b.Name) },
+	"email": func(a, b *api.Member) int { return strings.Compare(a.Email, b.Email) },
+	"role":  func(a, b *api.Member) int { return strings.Compare(a.Role, b.Role) },
+	"created": func(a, b *api.Member) int {
+		var at, bt time.Time
+		if a.CreatedAt != nil {
+			at = *a.CreatedAt
+		}
+		if b.CreatedAt != nil {
+			bt = *b.CreatedAt
+		}
+		return at.Compare(bt)
+	},
+}
+
+func listMembers(cmd *cobra.Command, args []string, opts listOptions) error {
+	if err := opts.validate(listSortNames(memberSorts)); err != nil {
+		return err
+	}
+
 	cc := cmd.Context()
 	term := ctx.Terminal(cc)
 	c, err := newAPIClient(cc)
@@ -34,35 +69,80 @@ func listMembers(cmd *cobra.Command, args []string) error {
 	}
 
 	members := []*api.Member{}
+	title := "*** Collaborators ***\n"
+
+	// Rows are printed as pages arrive, unless sorted
+	table := newStreamTable(term.IOOut(), title, memberColumns...)
+	enc := json.NewEncoder(term.IOOut())
+	emit := func(m *api.Member) error {
+		if !opts.streaming() {
+			members = append(members, m)
+		} else if opts.JSONLines {
+			return enc.Encode(m)
+		} else {
+			table.Row(memberRow(m)...)
+		}
+		return nil
+	}
 
-	// Paginate over package listings until no more pages
-	err = iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+	// Paginate over members until no more pages, or limit
+	count := 0
+	next, err := iteratePages(cc, !opts.streaming(), opts.Page, opts.Limit, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, int, error) {
 		resp, err := c.Members(cc, pageReq)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
+		}
+
+		for _, m := range resp.Members {
+			if err := emit(m); err != nil {
+				return nil, 0, err
+			}
 		}
 
-		members = append(members, resp.Members...)
-		return resp.Pagination, nil
+		count += len(resp.Members)
+		return resp.Pagination, len(resp.Members), nil
 	})
 
-	// Handle no packages
-	if len(members) == 0 {
+	// Handle no members
+	if count == 0 && !opts.JSONLines {
 		term.Println("No members found for this account")
-		return err
+		return finishListing(cmd, next, err)
 	}
 
-	// Print results
-	term.Printf("*** Collaborators ***\n")
-	w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
-	fmt.Fprintf(w, "name\trole\n")
+	sortListing(members, opts, memberSorts)
 
-	for _, m := range members {
-		fmt.Fprintf(w, "%s\t%s\n", m.Name, m.Role)
+	// Print sorted results
+	if opts.JSONLines {
+		for _, m := range members {
+			if jerr := enc.Encode(m); jerr != nil {
+				return jerr
+			}
+		}
+	} else if len(members) > 0 {
+		term.Printf("%s", title)
+		w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "name\temail\tusername\trole\tgranted_at\n")
+
+		for _, m := range members {
+			fmt.Fprintf(w, "%s\n", strings.Join(memberRow(m), "\t"))
+		}
+
+		w.Flush()
 	}
 
-	w.Flush()
-	return err
+	return finishListing(cmd, next, err)
+}
+
+var memberColumns = []streamColumn{
+	{"name", 20}, {"email", 28}, {"username", 16}, {"role", 6}, {"granted_at", 0},
+}
+
+func memberRow(m *api.Member) []string {
+	grantedAt := ""
+	if m.CreatedAt != nil {
+		grantedAt = timeStringWithAgo(*m.CreatedAt)
+	}
+	return []string{m.Name, m.Email, m.Username, m.Role, grantedAt}
 }
 
 // NewCmdSharingAdd generates the Cobra command for "sharing:add"
@@ -77,6 +157,11 @@ func NewCmdSharingAdd() *cobra.Command {
 				return fmt.Errorf("Please specify at least one collaborator")
 			}
 
+			role, err := validCollaboratorRole(roleFlag)
+			if err != nil {
+				return err
+			}
+
 			cc := cmd.Context()
 			term := ctx.Terminal(cc)
 			c, err := newAPIClient(cc)
@@ -86,7 +171,7 @@ func NewCmdSharingAdd() *cobra.Command {
 
 			var multiErr *multierror.Error
 			for _, name := range args {
-				err := c.AddCollaborator(cc, name, roleFlag)
+				err := c.AddCollaborator(cc, name, role)
 
 				if err != nil {
 					multiErr = multierror.Append(multiErr, err)
@@ -102,11 +187,84 @@ func NewCmdSharingAdd() *cobra.Command {
 	}
 
 	// Flags and options
-	addCmd.Flags().StringVar(&roleFlag, "role", "", "Collaborator role")
+	addCmd.Flags().StringVar(&roleFlag, "role", "", "Collaborator role ("+strings.Join(collaboratorRoles, ", ")+")")
 
 	return addCmd
 }
 
+// NewCmdSharingUpdate generates the Cobra command for "sharing:update"
+func NewCmdSharingUpdate() *cobra.Command {
+	var roleFlag string
+
+	updateCmd := &cobra.Command{
+		Use:   "update EMAIL",
+		Short: "Change role of a collaborator",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return fmt.Errorf("Please specify at least one collaborator")
+			} else if roleFlag == "" {
+				return fmt.Errorf("Please specify a --role")
+			}
+
+			role, err := validCollaboratorRole(roleFlag)
+			if err != nil {
+				return err
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			var multiErr *multierror.Error
+			for _, name := range args {
+				err := c.UpdateCollaborator(cc, name, role)
+
+				if err != nil {
+					multiErr = multierror.Append(multiErr, err)
+					log.Printf("Problem updating %q: %s\n", name, err)
+					continue
+				}
+
+				term.Printf("Updated %q to %s role\n", name, role)
+			}
+
+			return multiErr.Unwrap()
+		},
+	}
+
+	// Flags and options
+	updateCmd.Flags().StringVar(&roleFlag, "role", "", "Collaborator role ("+strings.Join(collaboratorRoles, ", ")+")")
+
+	return updateCmd
+}
+
+// Roles that can be granted to collaborators
+var collaboratorRoles = []string{"owner", "push", "pull"}
+
+// Normalized role, or an error suggesting a known role. Empty role is
+// allowed, leaving the default up to the server
+func validCollaboratorRole(role string) (string, error) {
+	role = strings.ToLower(role)
+	if role == "" {
+		return "", nil
+	}
+
+	for _, r := range collaboratorRoles {
+		if r == role {
+			return role, nil
+		}
+	}
+
+	if matches := closestMatches(role, collaboratorRoles); len(matches) > 0 {
+		return "", fmt.Errorf("Unknown role %q. Did you mean %q?", role, matches[0])
+	}
+
+	return "", fmt.Errorf("Unknown role %q. Available: %s", role, strings.Join(collaboratorRoles, ", "))
+}
+
 // NewCmdSharingRemove generates the Cobra command for "sharing:add"
 func NewCmdSharingRemove() *cobra.Command {
 	rmCmd := &cobra.Command{
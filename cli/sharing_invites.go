@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"text/tabwriter"
+)
+
+// NewCmdSharingInvites lists pending invitations
+func NewCmdSharingInvites() *cobra.Command {
+	invitesCmd := &cobra.Command{
+		Use:   "invites",
+		Short: "List pending invitations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			invites, err := allInvitations(cc, c)
+
+			// Handle no invitations
+			if len(invites) == 0 {
+				term.Println("No pending invitations for this account")
+				return err
+			}
+
+			// Print results
+			term.Printf("*** Pending invitations ***\n")
+			w := tabwriter.NewWriter(term.IOOut(), 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "id\temail\trole\tinvited_by\tinvited_at\n")
+
+			for _, i := range invites {
+				invitedBy := ""
+				if i.InvitedBy != nil {
+					invitedBy = i.InvitedBy.Name
+				}
+				invitedAt := timeStringWithAgo(i.CreatedAt)
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", i.ID, i.Email, i.Role, invitedBy, invitedAt)
+			}
+
+			w.Flush()
+			return err
+		},
+	}
+
+	invitesCmd.AddCommand(&cobra.Command{
+		Use:   "resend ID|EMAIL",
+		Short: "Send invitation email again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateInvitations(cmd, args, "Resent", func(cc context.Context, c *api.Client, id string) error {
+				return c.ResendInvitation(cc, id)
+			})
+		},
+	})
+
+	invitesCmd.AddCommand(&cobra.Command{
+		Use:   "cancel ID|EMAIL",
+		Short: "Cancel a pending invitation",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateInvitations(cmd, args, "Cancelled", func(cc context.Context, c *api.Client, id string) error {
+				return c.CancelInvitation(cc, id)
+			})
+		},
+	})
+
+	return invitesCmd
+}
+
+// Resend or cancel invitations, found by ID or email
+func updateInvitations(cmd *cobra.Command, args []string, verb string, fn func(context.Context, *api.Client, string) error) error {
+	if len(args) == 0 {
+		return fmt.Errorf("Please specify at least one invitation")
+	}
+
+	cc := cmd.Context()
+	term := ctx.Terminal(cc)
+	c, err := newAPIClient(cc)
+	if err != nil {
+		return err
+	}
+
+	// Emails need a lookup of invitation IDs
+	byEmail := map[string]string{}
+	for _, arg := range args {
+		if strings.Contains(arg, "@") {
+			invites, err := allInvitations(cc, c)
+			if err != nil {
+				return err
+			}
+			for _, i := range invites {
+				byEmail[strings.ToLower(i.Email)] = i.ID
+			}
+			break
+		}
+	}
+
+	var multiErr *multierror.Error
+	for _, arg := range args {
+		id := arg
+		if strings.Contains(arg, "@") {
+			if id = byEmail[strings.ToLower(arg)]; id == "" {
+				err := fmt.Errorf("No pending invitation for %q", arg)
+				multiErr = multierror.Append(multiErr, err)
+				log.Printf("Problem with %q: %s\n", arg, err)
+				continue
+			}
+		}
+
+		if err := fn(cc, c, id); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			log.Printf("Problem with %q: %s\n", arg, err)
+			continue
+		}
+
+		term.Printf("%s invitation for %q\n", verb, arg)
+	}
+
+	return multiErr.Unwrap()
+}
+
+func allInvitations(cc context.Context, c *api.Client) ([]*api.Invitation, error) {
+	invites := []*api.Invitation{}
+
+	// Paginate over invitations until no more pages
+	err := iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+		resp, err := c.Invitations(cc, pageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		invites = append(invites, resp.Invitations...)
+		return resp.Pagination, nil
+	})
+
+	return invites, err
+}
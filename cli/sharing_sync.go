@@ -0,0 +1,274 @@
+package cli
+
+import (
+	"github.com/gemfury/cli/api"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/pkg/terminal"
+	"github.com/hashicorp/go-multierror"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// NewCmdSharingSync syncs collaborators to the list in a YAML file
+func NewCmdSharingSync() *cobra.Command {
+	var dryRunFlag, forceFlag bool
+
+	syncCmd := &cobra.Command{
+		Use:   "sync FILE",
+		Short: "Sync collaborators to list in YAML file",
+		Long: `Sync collaborators to list in YAML file:
+
+  collaborators:
+    - user: alice@example.com   # Email or username
+      role: owner
+    - user: bob
+      role: push
+
+Collaborators missing from the file are removed, but the last
+owner of the account is never removed. Pending invitations count
+as collaborators, so they are not sent again. Invitations for users
+missing from the file are cancelled, and invitations with another
+role are sent again with the role from the file.
+
+With --dry-run, changes are shown without applying them, and the
+command exits with code 3 when collaborators have drifted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("Please specify a team file")
+			}
+
+			desired, err := readSharingSyncFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			cc := cmd.Context()
+			term := ctx.Terminal(cc)
+			c, err := newAPIClient(cc)
+			if err != nil {
+				return err
+			}
+
+			changes, err := sharingSyncChanges(cc, c, desired)
+			if err != nil {
+				return err
+			}
+
+			if len(changes) == 0 {
+				term.Println("Collaborators are up to date")
+				return nil
+			}
+
+			for _, ch := range changes {
+				term.Println(ch.String())
+			}
+
+			if dryRunFlag {
+				cmd.SilenceUsage = true
+				err := fmt.Errorf("Drift detected in %d collaborators", len(changes))
+				return exitError{err, exitCodeDrift}
+			}
+
+			if !forceFlag {
+				confirm := fmt.Sprintf("Apply %d changes to collaborators? [y/N]", len(changes))
+				if ok, err := terminal.PromptConfirm(term, confirm); !ok {
+					return err
+				}
+			}
+
+			var multiErr *multierror.Error
+			for _, ch := range changes {
+				if err := ch.Apply(cc, c); err != nil {
+					multiErr = multierror.Append(multiErr, fmt.Errorf("%s: %w", ch.user, err))
+				}
+			}
+
+			if multiErr == nil {
+				term.Printf("Applied %d changes to collaborators\n", len(changes))
+			}
+
+			return multiErr.ErrorOrNil()
+		},
+	}
+
+	// Flags and options
+	syncCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show changes, exit non-zero on drift")
+	syncCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation")
+
+	return syncCmd
+}
+
+// sharingSyncEntry is a collaborator in the "sharing sync" file
+type sharingSyncEntry struct {
+	User string `yaml:"user"`
+	Role string `yaml:"role"`
+}
+
+// sharingSyncFile is the YAML file for "sharing sync"
+type sharingSyncFile struct {
+	Collaborators []sharingSyncEntry `yaml:"collaborators"`
+}
+
+// Read desired roles keyed by lowercase email or username
+func readSharingSyncFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := sharingSyncFile{}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("Invalid file %q: %w", path, err)
+	} else if len(file.Collaborators) == 0 {
+		return nil, fmt.Errorf("No collaborators listed in %q", path)
+	}
+
+	desired := map[string]string{}
+	for i, e := range file.Collaborators {
+		user := strings.ToLower(e.User)
+		if user == "" {
+			return nil, fmt.Errorf("Entry #%d has no user", i+1)
+		} else if _, dup := desired[user]; dup {
+			return nil, fmt.Errorf("User %q is listed more than once", e.User)
+		}
+
+		role, err := validCollaboratorRole(e.Role)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.User, err)
+		} else if role == "" {
+			return nil, fmt.Errorf("%s: Missing role", e.User)
+		}
+
+		desired[user] = role
+	}
+
+	return desired, nil
+}
+
+// sharingSyncChange adds, removes, or changes role of a collaborator
+type sharingSyncChange struct {
+	user    string
+	oldRole string // Empty when adding
+	newRole string // Empty when removing
+	invite  string // Pending invitation ID, if not yet accepted
+}
+
+func (ch sharingSyncChange) String() string {
+	if ch.oldRole == "" {
+		return fmt.Sprintf("+ %s (%s)", ch.user, ch.newRole)
+	} else if ch.newRole == "" && ch.invite != "" {
+		return fmt.Sprintf("- %s (%s, invited)", ch.user, ch.oldRole)
+	} else if ch.newRole == "" {
+		return fmt.Sprintf("- %s (%s)", ch.user, ch.oldRole)
+	} else if ch.invite != "" {
+		return fmt.Sprintf("~ %s: %s => %s (invited)", ch.user, ch.oldRole, ch.newRole)
+	}
+	return fmt.Sprintf("~ %s: %s => %s", ch.user, ch.oldRole, ch.newRole)
+}
+
+func (ch sharingSyncChange) Apply(cc context.Context, c *api.Client) error {
+	if ch.invite != "" {
+		// Invitations can't be updated, so they're sent again with the new role
+		if err := c.CancelInvitation(cc, ch.invite); err != nil || ch.newRole == "" {
+			return err
+		}
+		return c.AddCollaborator(cc, ch.user, ch.newRole)
+	} else if ch.oldRole == "" {
+		return c.AddCollaborator(cc, ch.user, ch.newRole)
+	} else if ch.newRole == "" {
+		return c.RemoveCollaborator(cc, ch.user)
+	}
+	return c.UpdateCollaborator(cc, ch.user, ch.newRole)
+}
+
+// Compare desired roles with current members and pending invitations
+func sharingSyncChanges(cc context.Context, c *api.Client, desired map[string]string) ([]sharingSyncChange, error) {
+	members := []*api.Member{}
+	err := iterateAllPages(cc, func(pageReq *api.PaginationRequest) (*api.PaginationResponse, error) {
+		resp, err := c.Members(cc, pageReq)
+		if err != nil {
+			return nil, err
+		}
+
+		members = append(members, resp.Members...)
+		return resp.Pagination, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	invites, err := allInvitations(cc, c)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []sharingSyncChange{}
+	seen := map[string]bool{}
+	owners, currentOwners := 0, 0
+
+	for _, m := range members {
+		email, username := strings.ToLower(m.Email), strings.ToLower(m.Username)
+		user := m.Email
+		if user == "" {
+			user = m.Username
+		}
+
+		role, ok := desired[email]
+		if ok {
+			seen[email] = true
+		} else if role, ok = desired[username]; ok {
+			seen[username] = true
+		}
+
+		if !ok {
+			changes = append(changes, sharingSyncChange{user: user, oldRole: m.Role})
+		} else if role != m.Role {
+			changes = append(changes, sharingSyncChange{user: user, oldRole: m.Role, newRole: role})
+		}
+
+		// Invited owners can't manage the account until they accept
+		if role == "owner" {
+			owners++
+		}
+		if m.Role == "owner" {
+			currentOwners++
+		}
+	}
+
+	if owners == 0 && currentOwners > 0 {
+		return nil, fmt.Errorf("Refusing to remove the last owner of this account")
+	}
+
+	// Pending invitations are cancelled or sent again, like members
+	for _, i := range invites {
+		email := strings.ToLower(i.Email)
+		role, ok := desired[email]
+		seen[email] = true
+
+		if !ok {
+			changes = append(changes, sharingSyncChange{user: i.Email, oldRole: i.Role, invite: i.ID})
+		} else if role != i.Role {
+			changes = append(changes, sharingSyncChange{user: i.Email, oldRole: i.Role, newRole: role, invite: i.ID})
+		}
+	}
+
+	added := []string{}
+	for user := range desired {
+		if !seen[user] {
+			added = append(added, user)
+		}
+	}
+
+	sort.Strings(added)
+	for _, user := range added {
+		changes = append(changes, sharingSyncChange{user: user, newRole: desired[user]})
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,174 @@
+package cli_test
+
+import (
+	"github.com/gemfury/cli/cli"
+	"github.com/gemfury/cli/internal/ctx"
+	"github.com/gemfury/cli/internal/testutil"
+	"github.com/gemfury/cli/pkg/terminal"
+
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sharingSyncMembers = `[{
+	"email": "owner@example.com",
+	"username": "owner",
+	"role": "owner"
+}, {
+	"email": "dev@example.com",
+	"username": "dev",
+	"role": "push"
+}, {
+	"email": "former@example.com",
+	"username": "former",
+	"role": "pull"
+}]`
+
+const sharingSyncInvitations = `[
+	{ "id": "inv_a1b2c3", "email": "invited@example.com", "role": "push" },
+	{ "id": "inv_d4e5f6", "email": "leaving@example.com", "role": "pull" },
+	{ "id": "inv_g7h8i9", "email": "Promoted@example.com", "role": "pull" }
+]`
+
+const sharingSyncYAML = `collaborators:
+  - user: Owner@example.com
+    role: owner
+  - user: dev
+    role: pull
+  - user: invited@example.com
+    role: push
+  - user: promoted@example.com
+    role: push
+  - user: new@example.com
+    role: Push
+`
+
+// ==== sharing sync ====
+
+func TestSharingSyncCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	requests := []string{}
+	server := testSharingSyncServer(t, &requests)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	path := testSharingSyncFile(t, sharingSyncYAML)
+	err := runCommandNoErr(cc, []string{"sharing", "sync", path, "--force"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "~ dev@example.com: push => pull\n- former@example.com (pull)\n" +
+		"- leaving@example.com (pull, invited)\n~ Promoted@example.com: pull => push (invited)\n" +
+		"+ new@example.com (push)\nApplied 5 changes to collaborators\n"
+	if outStr := string(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+
+	expReqs := "PATCH /collaborators/dev@example.com?role=pull, " +
+		"DELETE /collaborators/former@example.com, " +
+		"DELETE /invitations/inv_d4e5f6, " +
+		"DELETE /invitations/inv_g7h8i9, PUT /collaborators/Promoted@example.com?role=push, " +
+		"PUT /collaborators/new@example.com?role=push"
+	if r := strings.Join(requests, ", "); r != expReqs {
+		t.Errorf("Expected requests %q, got %q", expReqs, r)
+	}
+}
+
+func TestSharingSyncCommandDryRun(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	requests := []string{}
+	server := testSharingSyncServer(t, &requests)
+	defer server.Close()
+
+	// Each case is file content, expected exit code, and error
+	cases := []struct {
+		content string
+		code    int
+		err     string
+	}{
+		{sharingSyncYAML, 3, "Drift detected in 5 collaborators"},
+		{"collaborators:\n- { user: owner, role: owner }\n- { user: dev, role: push }\n" +
+			"- { user: former, role: pull }\n- { user: invited@example.com, role: push }\n" +
+			"- { user: leaving@example.com, role: pull }\n- { user: promoted@example.com, role: owner }\n",
+			3, "Drift detected in 1 collaborators"},
+		{"collaborators:\n- { user: dev, role: push }\n", 1, "Refusing to remove the last owner of this account"},
+		{"collaborators:\n- { user: dev, role: admin }\n", 1, `dev: Unknown role "admin". Available: owner, push, pull`},
+		{"collaborators:\n- { user: owner, role: owner }\n- { user: dev, role: push }\n" +
+			"- { user: former, role: pull }\n- { user: invited@example.com, role: push }\n" +
+			"- { user: leaving@example.com, role: pull }\n- { user: promoted@example.com, role: pull }\n", 0, ""},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		path := testSharingSyncFile(t, c.content)
+		err := runCommand(cc, []string{"sharing", "sync", path, "--dry-run"})
+		if c.code == 0 && err != nil {
+			t.Errorf("%q: Unexpected error %s", c.content, err)
+		} else if c.code != 0 && (err == nil || err.Error() != c.err || cli.ExitCode(err) != c.code) {
+			t.Errorf("%q: Expected error %q with code %d, got %v", c.content, c.err, c.code, err)
+		}
+	}
+
+	if len(requests) > 0 {
+		t.Errorf("Expected no changes, got %q", requests)
+	}
+}
+
+func TestSharingSyncCommandUnauthorized(t *testing.T) {
+	path := testSharingSyncFile(t, sharingSyncYAML)
+	server := testSharingSyncServer(t, &[]string{})
+	testCommandLoginPreCheck(t, []string{"sharing", "sync", path, "-f"}, server)
+	server.Close()
+}
+
+func TestSharingSyncCommandForbidden(t *testing.T) {
+	path := testSharingSyncFile(t, sharingSyncYAML)
+	server := testutil.APIServer(t, "GET", "/members", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"sharing", "sync", path, "-f"}, server)
+	server.Close()
+}
+
+func testSharingSyncFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "team.yaml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// Serves members and invitations, and records collaborator and invitation updates
+func testSharingSyncServer(t *testing.T, requests *[]string) *httptest.Server {
+	return testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/members", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(sharingSyncMembers))
+		})
+		mux.HandleFunc("/invitations", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(sharingSyncInvitations))
+		})
+		mux.HandleFunc("/invitations/{id}", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			*requests = append(*requests, r.Method+" "+r.URL.String())
+			w.Write([]byte("{}"))
+		})
+		mux.HandleFunc("/collaborators/{name}", func(w http.ResponseWriter, r *http.Request) {
+			t.Logf("API Request: %s %s", r.Method, r.URL.String())
+			*requests = append(*requests, r.Method+" "+r.URL.String())
+			w.Write([]byte("{}"))
+		})
+	})
+}
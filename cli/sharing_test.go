@@ -5,6 +5,8 @@ import (
 	"github.com/gemfury/cli/internal/ctx"
 	"github.com/gemfury/cli/internal/testutil"
 	"github.com/gemfury/cli/pkg/terminal"
+
+	"encoding/json"
 	"net/http"
 	"strings"
 	"testing"
@@ -13,8 +15,10 @@ import (
 var sharingResponses = []string{`[{
 	"id": "acct_a1b2c3",
 	"name": "test-name",
+	"email": "test@example.com",
 	"username": "test-user",
-	"role": "owner"
+	"role": "owner",
+	"created_at": "2011-05-27T00:39:07+00:00"
 }]`, `[{
 	"id": "acct_z1y2x3",
 	"name": "collaborator",
@@ -42,12 +46,80 @@ func TestSharingCommandSuccess(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	exp := "test-name owner collaborator push"
+	exp := "test-name test@example.com test-user owner 2011-05-26 17:39 collaborator test-collab push"
 	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
 		t.Errorf("Expected output to include %q, got %q", exp, outStr)
 	}
 }
 
+func TestSharingCommandListOptions(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+
+	path := "/members"
+	server := testutil.APIServerPaginated(t, "GET", path, sharingResponses, 200)
+	defer server.Close()
+
+	// Each case is args, expected output, and next page
+	cases := []struct {
+		args []string
+		out  string
+		next string
+	}{
+		{[]string{"--sort", "name"}, "collaborator test-collab push test-name test@example.com test-user owner 2011-05-26 17:39", ""},
+		{[]string{"--sort", "created", "--reverse"}, "test-name test@example.com test-user owner 2011-05-26 17:39 collaborator test-collab push", ""},
+		{[]string{"--limit", "1"}, "test-name test@example.com test-user owner 2011-05-26 17:39", "More results with: --page p\n"},
+		{[]string{"--page", "p"}, "granted_at collaborator test-collab push", ""},
+	}
+
+	for _, c := range cases {
+		term := terminal.NewForTest()
+		cc := cli.TestContext(term, auth)
+		flags := ctx.GlobalFlags(cc)
+		flags.Endpoint = server.URL
+
+		err := runCommand(cc, append([]string{"sharing"}, c.args...))
+		if err != nil {
+			t.Fatalf("%v: %s", c.args, err)
+		}
+
+		if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, c.out) {
+			t.Errorf("%v: Expected output to include %q, got %q", c.args, c.out, outStr)
+		} else if errStr := string(term.ErrBytes()); errStr != c.next {
+			t.Errorf("%v: Expected stderr %q, got %q", c.args, c.next, errStr)
+		}
+	}
+}
+
+func TestSharingCommandJSONLines(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	server := testutil.APIServerPaginated(t, "GET", "/members", sharingResponses, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"sharing", "--jsonl", "--sort", "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := []string{"acct_z1y2x3", "acct_a1b2c3"}
+	lines := strings.Split(strings.TrimSpace(string(term.OutBytes())), "\n")
+	if len(lines) != len(exp) {
+		t.Fatalf("Expected %d lines, got %q", len(exp), lines)
+	}
+
+	for i, id := range exp {
+		m := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(lines[i]), &m); err != nil || m["id"] != id {
+			t.Errorf("Expected member %q, got %q (%v)", id, lines[i], err)
+		}
+	}
+}
+
 func TestSharingCommandUnauthorized(t *testing.T) {
 	server := testutil.APIServer(t, "GET", "/members", "[]", 200)
 	testCommandLoginPreCheck(t, []string{"sharing"}, server)
@@ -137,6 +209,169 @@ func TestSharingAddForbidden(t *testing.T) {
 	server.Close()
 }
 
+func TestSharingAddInvalidRole(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+	cc := cli.TestContext(term, auth)
+
+	err := runCommand(cc, []string{"sharing", "add", "added@example.com", "--role", "onwer"})
+	if exp := `Unknown role "onwer". Did you mean "owner"?`; err == nil || err.Error() != exp {
+		t.Errorf("Expected error %q, got %v", exp, err)
+	}
+}
+
+// ==== sharing update ====
+
+func TestSharingUpdateCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	// Fire up test server
+	roleQuery := ""
+	path := "/collaborators/member@example.com"
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			if m := r.Method; m != "PATCH" {
+				t.Errorf("Incorrect method: %q", m)
+			}
+			roleQuery = r.URL.Query().Get("role")
+			w.Write([]byte("{}"))
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"sharing", "update", "member@example.com", "--role", "Pull"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "Updated \"member@example.com\" to pull role\n"
+	if outStr := string(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	} else if r := roleQuery; r != "pull" {
+		t.Errorf(`Expected role to be "pull", got %q`, r)
+	}
+
+	// Role is required
+	err = runCommand(cc, []string{"sharing", "update", "member@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "--role") {
+		t.Errorf("Expected missing role error, got %v", err)
+	}
+}
+
+func TestSharingUpdateCommandUnauthorized(t *testing.T) {
+	path := "/collaborators/member@example.com"
+	server := testutil.APIServer(t, "PATCH", path, "{}", 200)
+	args := []string{"sharing", "update", "member@example.com", "--role", "push"}
+	testCommandLoginPreCheck(t, args, server)
+	server.Close()
+}
+
+func TestSharingUpdateForbidden(t *testing.T) {
+	path := "/collaborators/member@example.com"
+	server := testutil.APIServer(t, "PATCH", path, "{}", 403)
+	args := []string{"sharing", "update", "member@example.com", "--role", "push"}
+	testCommandForbiddenResponse(t, args, server)
+	server.Close()
+}
+
+// ==== sharing invites ====
+
+var invitesResponses = []string{`[{
+	"id": "inv_a1b2c3",
+	"email": "new@example.com",
+	"role": "push",
+	"created_at": "2011-05-27T00:39:07+00:00",
+	"invited_by": { "name": "test-name" }
+}]`, `[{
+	"id": "inv_z1y2x3",
+	"email": "other@example.com",
+	"role": "pull",
+	"created_at": "2011-05-27T00:39:07+00:00"
+}]`}
+
+func TestSharingInvitesCommandSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	// Fire up test server
+	server := testutil.APIServerPaginated(t, "GET", "/invitations", invitesResponses, 200)
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	err := runCommandNoErr(cc, []string{"sharing", "invites"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "inv_a1b2c3 new@example.com push test-name 2011-05-26 17:39 " +
+		"inv_z1y2x3 other@example.com pull 2011-05-26 17:39"
+	if outStr := compactString(term.OutBytes()); !strings.HasSuffix(outStr, exp) {
+		t.Errorf("Expected output to include %q, got %q", exp, outStr)
+	}
+}
+
+func TestSharingInvitesResendCancelSuccess(t *testing.T) {
+	auth := terminal.TestAuther("user", "abc123", nil)
+	term := terminal.NewForTest()
+
+	requests := []string{}
+	server := testutil.APIServerCustom(t, func(mux *http.ServeMux) {
+		mux.HandleFunc("/invitations", func(w http.ResponseWriter, r *http.Request) {
+			testutil.APIPaginatedResponse(t, w, r, invitesResponses, 200)
+		})
+		mux.HandleFunc("/invitations/{id}/{action...}", func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			w.Write([]byte("{}"))
+		})
+	})
+	defer server.Close()
+
+	cc := cli.TestContext(term, auth)
+	flags := ctx.GlobalFlags(cc)
+	flags.Endpoint = server.URL
+
+	// Invitations by ID or email
+	err := runCommandNoErr(cc, []string{"sharing", "invites", "resend", "inv_a1b2c3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = runCommandNoErr(cc, []string{"sharing", "invites", "cancel", "Other@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exp := "POST /invitations/inv_a1b2c3/resend, DELETE /invitations/inv_z1y2x3/"
+	if r := strings.Join(requests, ", "); r != exp {
+		t.Errorf("Expected requests %q, got %q", exp, r)
+	}
+
+	exp = `Resent invitation for "inv_a1b2c3" Cancelled invitation for "Other@example.com"`
+	if outStr := compactString(term.OutBytes()); outStr != exp {
+		t.Errorf("Expected output %q, got %q", exp, outStr)
+	}
+}
+
+func TestSharingInvitesCommandUnauthorized(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/invitations", "[]", 200)
+	testCommandLoginPreCheck(t, []string{"sharing", "invites"}, server)
+	server.Close()
+}
+
+func TestSharingInvitesCommandForbidden(t *testing.T) {
+	server := testutil.APIServer(t, "GET", "/invitations", "[]", 403)
+	testCommandForbiddenResponse(t, []string{"sharing", "invites"}, server)
+	server.Close()
+}
+
 // ==== sharing remove ====
 
 func TestSharingRemoveCommandSuccess(t *testing.T) {
@@ -0,0 +1,167 @@
+package cli
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	looseVersionRE = regexp.MustCompile(`^[vV]?(\d+(?:\.\d+)*)(.*)$`)
+	wildcardRE     = regexp.MustCompile(`(^|\.)[xX*](\.|$)`)
+)
+
+// looseVersion is a version parsed well enough to order and match versions
+// across package ecosystems (SemVer, PEP 440, Debian upstream, etc)
+type looseVersion struct {
+	nums []int
+	pre  string
+}
+
+func parseLooseVersion(s string) looseVersion {
+	s = strings.SplitN(s, "+", 2)[0] // Drop build metadata
+	m := looseVersionRE.FindStringSubmatch(s)
+	if m == nil {
+		return looseVersion{pre: s}
+	}
+
+	out := looseVersion{pre: strings.TrimLeft(m[2], "-.")}
+	for _, n := range strings.Split(m[1], ".") {
+		i, _ := strconv.Atoi(n)
+		out.nums = append(out.nums, i)
+	}
+
+	return out
+}
+
+// compare returns -1, 0, or 1. Prerelease sorts before its release.
+func (v looseVersion) compare(o looseVersion) int {
+	for i := 0; i < len(v.nums) || i < len(o.nums); i++ {
+		a, b := 0, 0
+		if i < len(v.nums) {
+			a = v.nums[i]
+		}
+		if i < len(o.nums) {
+			b = o.nums[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case v.pre == o.pre:
+		return 0
+	case v.pre == "":
+		return 1
+	case o.pre == "":
+		return -1
+	case v.pre < o.pre:
+		return -1
+	default:
+		return 1
+	}
+}
+
+// compareVersions orders two version strings
+func compareVersions(a, b string) int {
+	return parseLooseVersion(a).compare(parseLooseVersion(b))
+}
+
+// isVersionConstraint checks whether a version argument is a range,
+// such as "^2", "~1.2", ">=1.0 <2", or "1.x", rather than a version
+func isVersionConstraint(spec string) bool {
+	return strings.ContainsAny(spec, "^~<>=*, ") || wildcardRE.MatchString(spec)
+}
+
+// matchVersionConstraint checks version against all terms of a constraint.
+// Prereleases only match when the constraint mentions a prerelease.
+func matchVersionConstraint(constraint, version string) bool {
+	terms := strings.FieldsFunc(constraint, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	v := parseLooseVersion(version)
+	allowPre := false
+
+	for _, term := range terms {
+		raw := strings.TrimLeft(term, "<>=^~")
+		op := term[:len(term)-len(raw)]
+
+		if raw == "" || wildcardRE.MatchString(raw) {
+			if !matchVersionWildcard(raw, v) {
+				return false
+			}
+			continue
+		}
+
+		base := parseLooseVersion(raw)
+		allowPre = allowPre || base.pre != ""
+		if !matchVersionTerm(op, base, v) {
+			return false
+		}
+	}
+
+	return v.pre == "" || allowPre
+}
+
+// Wildcards match on numeric prefix: "*", "1.x", "1.2.*"
+func matchVersionWildcard(raw string, v looseVersion) bool {
+	for i, n := range parseLooseVersion(raw).nums {
+		if i >= len(v.nums) || v.nums[i] != n {
+			return false
+		}
+	}
+	return true
+}
+
+func matchVersionTerm(op string, base, v looseVersion) bool {
+	cmp := v.compare(base)
+
+	switch op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	case "^":
+		return cmp >= 0 && v.compare(caretCeiling(base)) < 0
+	case "~":
+		return cmp >= 0 && v.compare(tildeCeiling(base)) < 0
+	default: // "=" or "=="
+		return cmp == 0
+	}
+}
+
+// Caret allows changes that do not modify the left-most non-zero number
+func caretCeiling(base looseVersion) looseVersion {
+	for i, n := range base.nums {
+		if n != 0 || i == len(base.nums)-1 {
+			return bumpVersion(base, i)
+		}
+	}
+	return bumpVersion(base, 0)
+}
+
+// Tilde allows patch-level changes, or minor-level if only a major is given
+func tildeCeiling(base looseVersion) looseVersion {
+	if len(base.nums) < 2 {
+		return bumpVersion(base, 0)
+	}
+	return bumpVersion(base, 1)
+}
+
+// bumpVersion increments the number at index, truncating the rest.
+// Prerelease of "0" ensures the ceiling excludes its own prereleases.
+func bumpVersion(base looseVersion, index int) looseVersion {
+	nums := make([]int, index+1)
+	copy(nums, base.nums)
+	nums[index]++
+	return looseVersion{nums: nums, pre: "0"}
+}
@@ -36,3 +36,17 @@ func whoAMI(cc context.Context) (*api.AccountResponse, error) {
 
 	return c.WhoAmI(cc)
 }
+
+// Username of the --account, or of the current account
+func currentAccountName(cc context.Context, c *api.Client) (string, error) {
+	if account := ctx.GlobalFlags(cc).Account; account != "" {
+		return account, nil
+	}
+
+	resp, err := c.WhoAmI(cc)
+	if err != nil {
+		return "", err
+	}
+
+	return resp.Username, nil
+}
@@ -94,16 +94,50 @@ func NewCmdYank() *cobra.Command {
 	return yankCmd
 }
 
+// Common names for package kinds, as users know them
+var kindAliases = map[string]string{
+	"npm":  "js",
+	"pip":  "python",
+	"pypi": "python",
+	"gem":  "ruby",
+}
+
+// splitPackageKind extracts "kind:" from package name, if present
+func splitPackageKind(pkg string) (kind, name string) {
+	if at := strings.Index(pkg, ":"); at > 0 {
+		kind, name = pkg[0:at], pkg[at+1:]
+		if alias, ok := kindAliases[kind]; ok {
+			kind = alias
+		}
+		return kind, name
+	}
+	return "", pkg
+}
+
+// splitPackageVersion extracts "@version" from "[kind:]name@version".
+// The leading "@" of scoped names, like "npm:@scope/name", is kept.
+func splitPackageVersion(arg string) (pkg, version string) {
+	start := strings.Index(arg, ":") + 1
+	if at := strings.LastIndex(arg[start:], "@"); at > 0 {
+		return arg[:start+at], arg[start+at+1:]
+	}
+	return arg, ""
+}
+
+// filterVersions lists versions by "[kind:]name" and version (all, if empty)
 func filterVersions(cc context.Context, c *api.Client, pkg, ver string) ([]*api.Version, error) {
 	versions := []*api.Version{}
 
 	// Default search filters for listed versions
-	filter := url.Values(map[string][]string{"name": {pkg}, "version": {ver}})
+	filter := url.Values(map[string][]string{"name": {pkg}})
+	if ver != "" {
+		filter.Set("version", ver)
+	}
 
 	// Extract "kind:" from package name, if present
-	if at := strings.Index(pkg, ":"); at > 0 {
-		filter["name"] = []string{pkg[at+1:]}
-		filter["kind"] = []string{pkg[0:at]}
+	if kind, name := splitPackageKind(pkg); kind != "" {
+		filter.Set("name", name)
+		filter.Set("kind", kind)
 	}
 
 	// Paginate over package listings until no more pages
@@ -6,6 +6,7 @@ import (
 
 	"fmt"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
 )
@@ -16,7 +17,14 @@ var (
 )
 
 func main() {
-	cc := cli.CommandContext()
+	// Cancel context on first Ctrl-C, so commands can stop cleanly.
+	// Second Ctrl-C falls back to default behavior and terminates.
+	cc, stop := signal.NotifyContext(cli.CommandContext(), os.Interrupt)
+	go func() {
+		<-cc.Done()
+		stop()
+	}()
+
 	rootCmd := cli.NewRootCommand(cc)
 
 	// Populate version strings everywhere
@@ -31,7 +39,7 @@ func main() {
 	// Process command and deliver results
 	if err := rootCmd.ExecuteContext(cc); err != nil {
 		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
-		os.Exit(1)
+		os.Exit(cli.ExitCode(err))
 	}
 }
 
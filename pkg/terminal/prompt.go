@@ -21,6 +21,32 @@ func PromptConfirm(t Terminal, label string) (bool, error) {
 	return err == nil, err
 }
 
+// PromptTyped asks to type an exact value, such as a name, to confirm
+func PromptTyped(t Terminal, label, value string) (bool, error) {
+	out, err := t.RunPrompt(&promptui.Prompt{Label: label})
+	if errors.Is(err, promptui.ErrAbort) {
+		return false, nil
+	}
+	return err == nil && strings.TrimSpace(out) == value, err
+}
+
+// ReadSecret prompts for a value without echoing it when Stdin is
+// a terminal. Otherwise, it reads piped Stdin without trailing newline.
+func ReadSecret(t Terminal, label string) (string, error) {
+	stdin := t.IOIn()
+	if stdin == os.Stdin && readline.IsTerminal(int(os.Stdin.Fd())) {
+		return t.RunPrompt(&promptui.Prompt{Label: label, Mask: '*'})
+	}
+
+	b, err := io.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+
+	out := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(out, "\r"), nil
+}
+
 // PromptAnyKeyOrQuit reads either "q" or any key from Stdin
 func PromptAnyKeyOrQuit(t Terminal, prompt string) error {
 	if ch, err := stdinRawCharPrompt(t, prompt); err != nil {
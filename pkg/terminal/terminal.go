@@ -1,6 +1,7 @@
 package terminal
 
 import (
+	"github.com/chzyer/readline"
 	"github.com/gemfury/cli/pkg/browser"
 	"github.com/manifoldco/promptui"
 
@@ -12,9 +13,11 @@ import (
 type Terminal interface {
 	StartProgress(int64, string) Progress
 	RunPrompt(*promptui.Prompt) (string, error)
+	RunSelect(*promptui.Select) (int, string, error)
 	Printf(string, ...interface{}) (int, error)
 	Println(a ...interface{}) (n int, err error)
 	OpenBrowser(string) bool
+	IsInteractive() bool
 	IOIn() io.ReadCloser
 	IOErr() io.Writer
 	IOOut() io.Writer
@@ -60,6 +63,17 @@ func (t term) RunPrompt(p *promptui.Prompt) (string, error) {
 	return p.Run()
 }
 
+func (t term) RunSelect(s *promptui.Select) (int, string, error) {
+	s.Stdout = t.ioOut
+	s.Stdin = t.ioIn
+	return s.Run()
+}
+
+// IsInteractive is true when a user can answer prompts on Stdin
+func (t term) IsInteractive() bool {
+	return t.ioIn == os.Stdin && readline.IsTerminal(int(os.Stdin.Fd()))
+}
+
 func (t term) OpenBrowser(url string) bool {
 	return browser.Open(url)
 }
@@ -48,6 +48,11 @@ func (tt *testTerm) SetPromptResponses(p map[string]string) {
 	tt.prompts = p
 }
 
+// Interactive when test provides prompt responses
+func (tt *testTerm) IsInteractive() bool {
+	return len(tt.prompts) > 0
+}
+
 // Disable progress bar
 func (tt *testTerm) StartProgress(int64, string) Progress {
 	return noProgress{}
@@ -70,6 +75,25 @@ func (tt testTerm) RunPrompt(p *promptui.Prompt) (string, error) {
 	return "", io.EOF
 }
 
+// Selects item matching the response for label
+func (tt testTerm) RunSelect(s *promptui.Select) (int, string, error) {
+	if l, ok := s.Label.(string); ok {
+		if out, ok := tt.prompts[l]; ok {
+			if out == "ABORT" {
+				return -1, "", promptui.ErrAbort
+			}
+			if items, ok := s.Items.([]string); ok {
+				for i, item := range items {
+					if item == out {
+						return i, out, nil
+					}
+				}
+			}
+		}
+	}
+	return -1, "", io.EOF
+}
+
 // Implements Auther interface for testing
 func TestAuther(u, p string, err error) *testAuth {
 	return &testAuth{u, p, err}